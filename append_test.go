@@ -0,0 +1,38 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendEncodeDecode(t *testing.T) {
+	records := [][]byte{
+		[]byte("alpha beta gamma"),
+		[]byte("delta epsilon"),
+		[]byte(""),
+		bytes.Repeat([]byte("zeta "), 40),
+	}
+	tbl := Train(records)
+
+	// Accumulate encodes into one growing slice and compare against Encode.
+	var packed []byte
+	var want []byte
+	for i := range records {
+		packed = tbl.AppendEncode(packed, records[i])
+		want = append(want, tbl.EncodeAll(records[i])...)
+	}
+	if !bytes.Equal(packed, want) {
+		t.Fatalf("AppendEncode accumulation differs from individual encodes")
+	}
+
+	// AppendDecode preserves the prefix and appends decoded bytes.
+	prefix := []byte("prefix:")
+	comp := tbl.EncodeAll(records[0])
+	got := tbl.AppendDecode(append([]byte(nil), prefix...), comp)
+	if !bytes.Equal(got[:len(prefix)], prefix) {
+		t.Fatalf("AppendDecode clobbered prefix: %q", got)
+	}
+	if !bytes.Equal(got[len(prefix):], records[0]) {
+		t.Fatalf("AppendDecode payload mismatch: %q", got[len(prefix):])
+	}
+}
@@ -0,0 +1,50 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAppendBinary(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("append without buffer churn ", 100))}
+	tables := map[string]*Table{
+		"plain":    Train(corpus),
+		"extended": Train(corpus, WithExtendedCodes(true)),
+		"macros":   Train(corpus, WithMacros(true)),
+		"backrefs": Train(corpus, WithBackrefs(64)),
+	}
+	for name, tbl := range tables {
+		want, err := tbl.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: MarshalBinary: %v", name, err)
+		}
+		got, err := tbl.AppendBinary(nil)
+		if err != nil {
+			t.Fatalf("%s: AppendBinary: %v", name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s: AppendBinary differs from MarshalBinary", name)
+		}
+
+		// Appending preserves the existing prefix and lands right after it.
+		prefix := []byte("header:")
+		out, err := tbl.AppendBinary(append([]byte(nil), prefix...))
+		if err != nil {
+			t.Fatalf("%s: AppendBinary with prefix: %v", name, err)
+		}
+		if !bytes.HasPrefix(out, prefix) || !bytes.Equal(out[len(prefix):], want) {
+			t.Fatalf("%s: AppendBinary clobbered the destination prefix", name)
+		}
+
+		// With enough spare capacity the destination is reused in place.
+		dst := make([]byte, 0, tbl.SerializedLen())
+		out, err = tbl.AppendBinary(dst)
+		if err != nil {
+			t.Fatalf("%s: AppendBinary into sized buffer: %v", name, err)
+		}
+		if &out[0] != &dst[:1][0] {
+			t.Fatalf("%s: AppendBinary reallocated despite sufficient capacity", name)
+		}
+	}
+}
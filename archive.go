@@ -0,0 +1,129 @@
+package fsst
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Archive provides random access into a container produced by
+// ContainerWriter. OpenArchive indexes the frames once — logical (decoded)
+// offset and encoded extent per record — so a read at any decoded offset
+// touches only the frames covering it, never the whole archive.
+type Archive struct {
+	table  Table
+	frames []archiveFrame
+	size   int64 // total decoded size
+}
+
+// archiveFrame locates one container record in both coordinate spaces.
+type archiveFrame struct {
+	payload    []byte // encoded payload, aliasing the archive bytes
+	logicalOff int64  // decoded offset of the record's first byte
+	decodedLen int
+}
+
+// OpenArchive indexes a complete container held in memory (a read-only
+// memory-mapped file works too: data is never written, see DecodeMmap).
+// Every record's CRC is verified up front, so later reads cannot surface
+// corruption; a truncated or damaged record yields ErrBadRecord. The archive
+// aliases data, which must not be mutated while the archive or any reader
+// from it is in use.
+func OpenArchive(data []byte) (*Archive, error) {
+	a := &Archive{}
+	n, err := a.table.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	a.table.WarmDecoder()
+
+	pos := int(n)
+	for pos < len(data) {
+		payloadLen, hdrLen := binary.Uvarint(data[pos:])
+		if hdrLen <= 0 {
+			return nil, ErrBadRecord
+		}
+		rest := len(data) - pos - hdrLen
+		if rest < 4 || payloadLen > uint64(rest-4) {
+			return nil, ErrBadRecord
+		}
+		payload := data[pos+hdrLen : pos+hdrLen+int(payloadLen)]
+		crcOff := pos + hdrLen + int(payloadLen)
+		if crc32.Checksum(payload, containerCRC) != binary.LittleEndian.Uint32(data[crcOff:]) {
+			return nil, ErrBadRecord
+		}
+		decodedLen := a.table.DecodedLen(payload)
+		a.frames = append(a.frames, archiveFrame{
+			payload:    payload,
+			logicalOff: a.size,
+			decodedLen: decodedLen,
+		})
+		a.size += int64(decodedLen)
+		pos = crcOff + 4
+	}
+	return a, nil
+}
+
+// Size returns the total decoded size of the archive in bytes.
+func (a *Archive) Size() int64 { return a.size }
+
+// Records returns the number of records in the archive.
+func (a *Archive) Records() int { return len(a.frames) }
+
+// Table returns the table carried in the archive header.
+func (a *Archive) Table() *Table { return &a.table }
+
+// ReaderAt returns a view of the archive's decoded bytes implementing
+// io.ReaderAt, so io.SectionReader and anything else built on positioned
+// reads works over compressed data. A read decodes only the frames covering
+// the requested range; the last decoded frame stays cached, so
+// sequential-ish access decodes each frame once. The reader is safe for
+// concurrent ReadAt calls and holds its own cache — take one reader per
+// goroutine to avoid cache contention.
+func (a *Archive) ReaderAt() io.ReaderAt {
+	return &archiveReaderAt{a: a, cached: -1}
+}
+
+var errNegativeOffset = errors.New("fsst: Archive.ReadAt: negative offset")
+
+type archiveReaderAt struct {
+	a      *Archive
+	mu     sync.Mutex
+	cached int    // index of the frame decoded in buf, -1 = none
+	buf    []byte // decoded bytes of frames[cached], reused across reads
+}
+
+// ReadAt implements io.ReaderAt over the decoded byte stream. It returns
+// io.EOF with a short count when the range extends past the archive.
+func (r *archiveReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+	frames := r.a.frames
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for n < len(p) {
+		if off >= r.a.size {
+			return n, io.EOF
+		}
+		// First frame whose decoded range ends past off; zero-length records
+		// are skipped by construction.
+		i := sort.Search(len(frames), func(i int) bool {
+			return frames[i].logicalOff+int64(frames[i].decodedLen) > off
+		})
+		if r.cached != i {
+			r.buf = r.a.table.Decode(r.buf, frames[i].payload)
+			r.cached = i
+		}
+		copied := copy(p[n:], r.buf[off-frames[i].logicalOff:])
+		n += copied
+		off += int64(copied)
+	}
+	return n, nil
+}
@@ -0,0 +1,89 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// buildArchive writes records through ContainerWriter and returns the raw
+// archive plus the decoded concatenation for reference.
+func buildArchive(t *testing.T, records [][]byte) ([]byte, []byte) {
+	t.Helper()
+	tbl := Train(records)
+	var buf bytes.Buffer
+	cw, err := NewContainerWriter(&buf, tbl)
+	if err != nil {
+		t.Fatalf("NewContainerWriter: %v", err)
+	}
+	var plain []byte
+	for _, rec := range records {
+		if err := cw.WriteRecord(rec); err != nil {
+			t.Fatalf("WriteRecord: %v", err)
+		}
+		plain = append(plain, rec...)
+	}
+	return buf.Bytes(), plain
+}
+
+func TestArchiveReaderAt(t *testing.T) {
+	var records [][]byte
+	for i := 0; i < 20; i++ {
+		records = append(records, []byte(strings.Repeat("archive record payload text ", i+1)))
+	}
+	raw, plain := buildArchive(t, records)
+
+	a, err := OpenArchive(raw)
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+	if a.Size() != int64(len(plain)) || a.Records() != len(records) {
+		t.Fatalf("Size/Records = %d/%d, want %d/%d", a.Size(), a.Records(), len(plain), len(records))
+	}
+
+	r := a.ReaderAt()
+
+	// Whole-archive read through io.SectionReader.
+	got, err := io.ReadAll(io.NewSectionReader(r, 0, a.Size()))
+	if err != nil || !bytes.Equal(got, plain) {
+		t.Fatalf("full read: err=%v, match=%v", err, bytes.Equal(got, plain))
+	}
+
+	// Random positioned reads, including ranges that straddle frames.
+	rng := rand.New(rand.NewSource(rngSeed))
+	p := make([]byte, 0, 700)
+	for i := 0; i < 200; i++ {
+		off := rng.Int63n(a.Size())
+		p = p[:1+rng.Intn(cap(p))]
+		n, err := r.ReadAt(p, off)
+		want := plain[off:min(off+int64(len(p)), int64(len(plain)))]
+		if n != len(want) || !bytes.Equal(p[:n], want) {
+			t.Fatalf("ReadAt(%d, %d): n=%d, want %d", len(p), off, n, len(want))
+		}
+		if end := off+int64(len(p)) > a.Size(); (err == io.EOF) != end {
+			t.Fatalf("ReadAt(%d, %d): err=%v, past-end=%v", len(p), off, err, end)
+		}
+	}
+
+	// Past-the-end and negative offsets.
+	if n, err := r.ReadAt(p[:1], a.Size()); n != 0 || err != io.EOF {
+		t.Fatalf("ReadAt at EOF: n=%d err=%v", n, err)
+	}
+	if _, err := r.ReadAt(p[:1], -1); err == nil {
+		t.Fatal("negative offset not rejected")
+	}
+}
+
+func TestArchiveCorruptRecord(t *testing.T) {
+	raw, _ := buildArchive(t, [][]byte{[]byte(strings.Repeat("corruptible record ", 40))})
+	raw[len(raw)-1] ^= 0x01 // flip a CRC bit
+	if _, err := OpenArchive(raw); !errors.Is(err, ErrBadRecord) {
+		t.Fatalf("OpenArchive on corrupt record: err=%v, want ErrBadRecord", err)
+	}
+	if _, err := OpenArchive(raw[:len(raw)-3]); !errors.Is(err, ErrBadRecord) {
+		t.Fatalf("OpenArchive on truncated record: err=%v, want ErrBadRecord", err)
+	}
+}
@@ -0,0 +1,87 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestASCIIOnlyDetection(t *testing.T) {
+	ascii := Train([][]byte{[]byte(strings.Repeat("plain seven bit text ", 50))})
+	if !ascii.asciiOnly {
+		t.Fatalf("asciiOnly not set on 7-bit corpus")
+	}
+	utf8 := Train([][]byte{[]byte(strings.Repeat("héllo wörld ", 50))})
+	if utf8.asciiOnly {
+		t.Fatalf("asciiOnly set on corpus with high-bit bytes")
+	}
+}
+
+func TestASCIIOnlyEncodeMatchesGeneral(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("plain seven bit text ", 50))})
+	if !tbl.asciiOnly {
+		t.Fatalf("expected asciiOnly table")
+	}
+
+	// Mixed input: high-bit bytes must escape identically on both paths.
+	input := []byte("plain seven bit text \xc3\xa9 and more plain text \xff")
+	got := tbl.Encode(nil, input)
+
+	tbl.asciiOnly = false
+	want := tbl.Encode(nil, input)
+	tbl.asciiOnly = true
+	if !bytes.Equal(got, want) {
+		t.Fatalf("ASCII path output differs from general path")
+	}
+	if dec := tbl.Decode(nil, got); !bytes.Equal(dec, input) {
+		t.Fatalf("roundtrip mismatch")
+	}
+}
+
+func TestASCIIOnlySerialized(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("plain seven bit text ", 50))})
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFrom(&buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !tbl2.asciiOnly {
+		t.Fatalf("asciiOnly hint lost in serialization")
+	}
+}
+
+func BenchmarkEncodeASCII(b *testing.B) {
+	data, err := os.ReadFile("testdata/art_of_war.txt")
+	if err != nil {
+		b.Skip("corpus not available")
+	}
+	// The corpora carry a few high-bit bytes (typographic quotes); clear the
+	// high bit so the table trains as ASCII-only.
+	for i := range data {
+		data[i] &= 0x7F
+	}
+	tbl := Train([][]byte{data})
+	buf := make([]byte, 2*len(data)+fsstOutputPadding)
+
+	b.Run("ascii", func(b *testing.B) {
+		if !tbl.asciiOnly {
+			b.Skip("corpus is not pure ASCII")
+		}
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			tbl.Encode(buf, data)
+		}
+	})
+	b.Run("general", func(b *testing.B) {
+		tbl.asciiOnly = false
+		defer func() { tbl.asciiOnly = true }()
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			tbl.Encode(buf, data)
+		}
+	})
+}
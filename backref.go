@@ -0,0 +1,162 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// Back-references add a small LZ-style matching layer on top of symbol
+// coding: an exact repeat of recently decoded bytes within a bounded window
+// is emitted as a three-byte sequence instead of being re-coded symbol by
+// symbol. Symbols top out at 8 bytes, so repeats just past that limit —
+// repeated timestamps in log lines are the canonical case — otherwise cost
+// their full symbol-coded length on every occurrence. Enabled via
+// WithBackrefs; experimental, ratio-focused, and gated by its own format
+// flag. The sequence is [marker][distance][length] using the first spare
+// single-byte code as the marker, so backrefs need at least one free code
+// (nSymbols < 255) and are incompatible with extended codes and macros,
+// which claim the same spare space.
+
+// fsstBackrefMinLen is the shortest repeat worth a back-reference. The
+// triple costs 3 output bytes; shorter runs symbol-code to about that much
+// anyway, so matching them would slow the encoder for no ratio gain.
+const fsstBackrefMinLen = 16
+
+// fsstBackrefMaxWindow bounds the match distance so it fits the single
+// distance byte.
+const fsstBackrefMaxWindow = 255
+
+// WithBackrefs enables back-reference matching with the given window: during
+// encode, an exact repeat of at least 16 bytes whose source lies within
+// window bytes behind the current position is emitted as one marker+
+// distance+length triple, and the decoder expands it by copying from its own
+// output. window is clamped to [1..255]; 0 or less disables. Even a small
+// window helps data with short-period repetition, at the cost of an
+// O(window) scan per input position during encode. Tables trained with
+// backrefs use a distinct serialization format flag. Incompatible with
+// WithExtendedCodes and WithMacros, which win if both are set; backrefs are
+// also dropped when training fills all 255 codes, leaving no marker.
+// EncodeStream and FrozenTable.Encode skip backref matching (like macro
+// rewriting for streams, matching needs state those paths don't keep); their
+// plain output still decodes correctly with a backref table.
+func WithBackrefs(window int) TrainOption {
+	return func(cfg *trainConfig) { cfg.backrefWindow = window }
+}
+
+// encodeBackrefs compresses input with back-reference matching: literal
+// stretches go through the regular chunk pipeline, repeats become triples.
+func (t *Table) encodeBackrefs(buf, input []byte) []byte {
+	t.ensureEncoder()
+
+	// Same worst case as Encode: every literal byte may escape, and each
+	// triple replaces at least fsstBackrefMinLen bytes with 3.
+	if cap(buf) < 2*len(input)+fsstOutputPadding {
+		buf = make([]byte, 2*len(input)+fsstOutputPadding)
+	} else {
+		buf = buf[:cap(buf)]
+	}
+
+	marker := uint8(t.nSymbols)
+	window := int(t.backrefWindow)
+	outPos := 0
+	literal := 0 // start of the pending literal stretch
+	for pos := literal; pos+fsstBackrefMinLen <= len(input); {
+		// Greedy: the longest repeat starting here, nearest source wins ties
+		// (cheap and deterministic; all distances cost the same byte anyway).
+		bestDist, bestLen := 0, 0
+		for dist := 1; dist <= window && dist <= pos; dist++ {
+			run := 0
+			for pos+run < len(input) && run < 255 && input[pos+run] == input[pos+run-dist] {
+				run++
+			}
+			if run > bestLen {
+				bestDist, bestLen = dist, run
+			}
+		}
+		if bestLen < fsstBackrefMinLen {
+			pos++
+			continue
+		}
+		outPos = t.EncodeAppendAt(buf, outPos, input[literal:pos])
+		buf[outPos] = marker
+		buf[outPos+1] = uint8(bestDist)
+		buf[outPos+2] = uint8(bestLen)
+		outPos += 3
+		pos += bestLen
+		literal = pos
+	}
+	outPos = t.EncodeAppendAt(buf, outPos, input[literal:])
+	return buf[:outPos]
+}
+
+// decodeBackrefs decompresses a backref-enabled code stream: marker triples
+// copy from already-decoded output, everything else decodes as usual. The
+// byte-wise copy deliberately handles overlapping sources (distance shorter
+// than length repeats the period, RLE-style).
+func (t *Table) decodeBackrefs(buf, src []byte) []byte {
+	t.ensureDecoder()
+
+	if buf == nil {
+		buf = make([]byte, 0, len(src)*4+8)
+	} else {
+		buf = buf[:0] // Reset length but keep capacity
+	}
+
+	marker := uint8(t.nSymbols)
+	var tmp [8]byte
+	for srcPos := 0; srcPos < len(src); {
+		code := src[srcPos]
+		srcPos++
+		switch {
+		case code == fsstEscapeCode:
+			if srcPos >= len(src) {
+				return buf
+			}
+			buf = append(buf, src[srcPos])
+			srcPos++
+		case code == marker:
+			if srcPos+1 >= len(src) {
+				return buf // truncated triple
+			}
+			dist := int(src[srcPos])
+			length := int(src[srcPos+1])
+			srcPos += 2
+			if dist < 1 || dist > len(buf) {
+				continue // malformed distance: skip rather than read out of bounds
+			}
+			for range length {
+				buf = append(buf, buf[len(buf)-dist])
+			}
+		default:
+			binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[code])
+			buf = append(buf, tmp[:t.decLen[code]]...)
+		}
+	}
+	return buf
+}
+
+// writeToBackrefs serializes a backref-enabled Table: the plain layout with
+// the backref format flag, followed by the one-byte window.
+func (t *Table) writeToBackrefs(w io.Writer) (int64, error) {
+	n, err := t.writeToPlain(w, fsstFormatBackrefs)
+	if err != nil {
+		return n, err
+	}
+	nn, err := w.Write([]byte{t.backrefWindow})
+	return n + int64(nn), err
+}
+
+// readBackrefTrailer finishes deserializing a backref-enabled Table after
+// the symbol block has been read.
+func (t *Table) readBackrefTrailer(r io.Reader, n int64) (int64, error) {
+	var win [1]byte
+	if _, err := io.ReadFull(r, win[:]); err != nil {
+		return n, err
+	}
+	n++
+	if win[0] > 0 && int(t.nSymbols) >= fsstMaxSymbols {
+		return n, ErrBadFormat // no spare code left for the marker
+	}
+	t.backrefWindow = win[0]
+	return n, nil
+}
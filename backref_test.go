@@ -0,0 +1,136 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// backrefCorpus mimics log data: every line starts with the same timestamp,
+// so lines repeat each other at a distance of one line length — too far for
+// 8-byte symbols, well inside a small backref window.
+func backrefCorpus() [][]byte {
+	var inputs [][]byte
+	for i := 0; i < 100; i++ {
+		inputs = append(inputs, []byte(fmt.Sprintf(
+			"2024-05-01T12:00:00Z level=info msg=\"request served\" id=%04d\n", i)))
+	}
+	return inputs
+}
+
+func TestBackrefRoundtrip(t *testing.T) {
+	inputs := backrefCorpus()
+	table := Train(inputs, WithBackrefs(128))
+	if table.backrefWindow == 0 {
+		t.Fatal("expected backref window to be set")
+	}
+
+	input := []byte(strings.Repeat("2024-05-01T12:00:00Z level=info msg=\"request served\"\n", 40))
+	compressed := table.Encode(nil, input)
+	if got := table.Decode(nil, compressed); !bytes.Equal(got, input) {
+		t.Fatalf("roundtrip mismatch: got %d bytes, want %d", len(got), len(input))
+	}
+	if got := table.DecodedLen(compressed); got != len(input) {
+		t.Errorf("DecodedLen = %d, want %d", got, len(input))
+	}
+
+	// Short inputs below the minimum match length take the plain path.
+	short := []byte("level=info")
+	if got := table.Decode(nil, table.Encode(nil, short)); !bytes.Equal(got, short) {
+		t.Fatalf("short roundtrip mismatch: got %q, want %q", got, short)
+	}
+}
+
+func TestBackrefImprovesRatio(t *testing.T) {
+	inputs := backrefCorpus()
+	plain := Train(inputs)
+	backref := Train(inputs, WithBackrefs(128))
+
+	input := bytes.Join(backrefCorpus(), nil)
+	plainLen := len(plain.Encode(nil, input))
+	backrefLen := len(backref.Encode(nil, input))
+	if backrefLen >= plainLen {
+		t.Errorf("backref encoding %d bytes, plain %d; expected backrefs to shrink output", backrefLen, plainLen)
+	}
+}
+
+func TestBackrefSerializationRoundtrip(t *testing.T) {
+	inputs := backrefCorpus()
+	table := Train(inputs, WithBackrefs(64))
+	if table.backrefWindow == 0 {
+		t.Fatal("expected backref window to be set")
+	}
+
+	var buf bytes.Buffer
+	if _, err := table.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if got := table.SerializedLen(); got != buf.Len() {
+		t.Errorf("SerializedLen = %d, WriteTo wrote %d", got, buf.Len())
+	}
+	restored := &Table{}
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if restored.backrefWindow != table.backrefWindow {
+		t.Fatalf("restored window %d, want %d", restored.backrefWindow, table.backrefWindow)
+	}
+	if !restored.Equal(table) {
+		t.Fatal("restored table not Equal to original")
+	}
+
+	input := []byte(strings.Repeat("2024-05-01T12:00:00Z level=info msg=\"request served\"\n", 20))
+	want := table.Encode(nil, input)
+	got := restored.Encode(nil, input)
+	if !bytes.Equal(got, want) {
+		t.Fatal("restored table produces different encoding")
+	}
+	if !bytes.Equal(restored.Decode(nil, want), input) {
+		t.Fatal("restored table fails to decode original encoding")
+	}
+}
+
+func TestBackrefDecodeToWriter(t *testing.T) {
+	table := Train(backrefCorpus(), WithBackrefs(128))
+
+	// Long enough to force several flushes, so back-references must resolve
+	// against the retained window rather than already-flushed bytes.
+	input := []byte(strings.Repeat("2024-05-01T12:00:00Z level=info msg=\"request served\"\n", 3000))
+	compressed := table.Encode(nil, input)
+
+	var out bytes.Buffer
+	n, err := table.DecodeToWriter(&out, compressed)
+	if err != nil {
+		t.Fatalf("DecodeToWriter failed: %v", err)
+	}
+	if n != int64(len(input)) || !bytes.Equal(out.Bytes(), input) {
+		t.Fatalf("DecodeToWriter wrote %d bytes, want %d", n, len(input))
+	}
+}
+
+func TestBackrefMalformedStreams(t *testing.T) {
+	table := Train(backrefCorpus(), WithBackrefs(64))
+	marker := uint8(table.nSymbols)
+
+	input := []byte(strings.Repeat("2024-05-01T12:00:00Z level=info msg=\"request served\"\n", 20))
+	compressed := table.Encode(nil, input)
+	if !bytes.Contains(compressed, []byte{marker}) {
+		t.Fatal("expected a back-reference in the encoded stream")
+	}
+
+	// Truncating anywhere — including mid-triple — must not panic or read
+	// out of bounds, and the decode must stay a prefix of the full output.
+	for cut := 0; cut < len(compressed); cut++ {
+		got := table.Decode(nil, compressed[:cut])
+		if !bytes.HasPrefix(input, got) {
+			t.Fatalf("truncated decode at %d is not a prefix of the input", cut)
+		}
+	}
+
+	// A distance pointing before the start of the output is skipped.
+	bogus := []byte{marker, 200, 50}
+	if got := table.Decode(nil, bogus); len(got) != 0 {
+		t.Fatalf("bogus back-reference decoded %d bytes, want 0", len(got))
+	}
+}
@@ -0,0 +1,79 @@
+package fsst
+
+import "container/list"
+
+// CachingDecoder wraps a Table with a small LRU over decoded values, keyed
+// by the compressed bytes. Low-cardinality columns decode the same few
+// values constantly; a hit returns the memoized output and skips the decode
+// entirely, a miss falls through to Decode and caches the result. The
+// returned slices are owned by the cache and must be treated as read-only —
+// that sharing is where the speedup comes from. Like Table's lazy
+// initialization, a CachingDecoder is not safe for concurrent use; give each
+// goroutine its own.
+type CachingDecoder struct {
+	t        *Table
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently used
+	hits     uint64
+	misses   uint64
+}
+
+type cacheEntry struct {
+	key string
+	val []byte
+}
+
+// fsstDecodeCacheSize is the default CachingDecoder capacity.
+const fsstDecodeCacheSize = 256
+
+// NewCachingDecoder wraps t with an LRU holding up to capacity decoded
+// values; capacity <= 0 selects the default of 256.
+func NewCachingDecoder(t *Table, capacity int) *CachingDecoder {
+	if capacity <= 0 {
+		capacity = fsstDecodeCacheSize
+	}
+	return &CachingDecoder{
+		t:        t,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Decode returns the decoded form of src, from the cache when the exact
+// compressed bytes were seen recently. The result must not be modified.
+func (d *CachingDecoder) Decode(src []byte) []byte {
+	if e, ok := d.entries[string(src)]; ok {
+		d.hits++
+		d.order.MoveToFront(e)
+		return e.Value.(*cacheEntry).val
+	}
+	d.misses++
+	out := d.t.Decode(nil, src)
+
+	key := string(src) // owned copy of the compressed bytes
+	d.entries[key] = d.order.PushFront(&cacheEntry{key: key, val: out})
+	if d.order.Len() > d.capacity {
+		oldest := d.order.Back()
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return out
+}
+
+// CacheStats reports how the cache has performed since creation.
+type CacheStats struct {
+	Hits    uint64  // decodes served from the cache
+	Misses  uint64  // decodes that fell through to the table
+	HitRate float64 // Hits / (Hits+Misses); 0 before any decode
+}
+
+// Stats returns the hit-rate counters accumulated so far.
+func (d *CachingDecoder) Stats() CacheStats {
+	s := CacheStats{Hits: d.hits, Misses: d.misses}
+	if total := d.hits + d.misses; total > 0 {
+		s.HitRate = float64(d.hits) / float64(total)
+	}
+	return s
+}
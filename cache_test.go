@@ -0,0 +1,53 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCachingDecoder(t *testing.T) {
+	corpus := []byte(strings.Repeat("ACTIVE INACTIVE PENDING DELETED ", 100))
+	tbl := Train([][]byte{corpus})
+	values := [][]byte{[]byte("ACTIVE"), []byte("INACTIVE"), []byte("PENDING")}
+	var comps [][]byte
+	for _, v := range values {
+		comps = append(comps, tbl.Encode(nil, v))
+	}
+
+	d := NewCachingDecoder(tbl, 8)
+	for round := 0; round < 10; round++ {
+		for i, comp := range comps {
+			if got := d.Decode(comp); !bytes.Equal(got, values[i]) {
+				t.Fatalf("round %d: Decode(%q) = %q, want %q", round, comp, got, values[i])
+			}
+		}
+	}
+	stats := d.Stats()
+	if stats.Misses != uint64(len(comps)) {
+		t.Fatalf("misses = %d, want %d (one per distinct value)", stats.Misses, len(comps))
+	}
+	if stats.Hits != uint64(9*len(comps)) {
+		t.Fatalf("hits = %d, want %d", stats.Hits, 9*len(comps))
+	}
+	if stats.HitRate < 0.89 || stats.HitRate > 0.91 {
+		t.Fatalf("hit rate = %.2f, want 0.90", stats.HitRate)
+	}
+}
+
+func TestCachingDecoderEviction(t *testing.T) {
+	corpus := []byte(strings.Repeat("value cardinality above capacity ", 100))
+	tbl := Train([][]byte{corpus})
+
+	d := NewCachingDecoder(tbl, 4)
+	for i := 0; i < 100; i++ {
+		v := []byte(fmt.Sprintf("value %d", i%10)) // cardinality 10 > capacity 4
+		if got := d.Decode(tbl.Encode(nil, v)); !bytes.Equal(got, v) {
+			t.Fatalf("Decode under eviction = %q, want %q", got, v)
+		}
+	}
+	if len(d.entries) != 4 || d.order.Len() != 4 {
+		t.Fatalf("cache holds %d/%d entries, want capacity 4", len(d.entries), d.order.Len())
+	}
+}
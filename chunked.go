@@ -0,0 +1,46 @@
+package fsst
+
+// EncodeChunked compresses input and partitions the encoded bytes into frames
+// of at most frameSize bytes for framed transports. Frame boundaries always
+// land on code boundaries: an escape (in extended mode a prefixed high code,
+// on a backref table a marker+distance+length triple) spans multiple bytes
+// and is never split across frames, so every frame is independently
+// well-formed and decoding the concatenation reproduces the input. Note that
+// backref frames are well-formed but not independently decodable — a triple
+// may copy from bytes decoded in an earlier frame, so only the concatenation
+// decodes correctly. frameSize must be at least 2 (3 on a backref table, so
+// a triple fits a frame); smaller values are raised.
+func (t *Table) EncodeChunked(input []byte, frameSize int) [][]byte {
+	frameSize = max(frameSize, 2)
+	hasMarker := t.backrefWindow > 0 && int(t.nSymbols) < fsstMaxSymbols
+	marker := byte(t.nSymbols)
+	if hasMarker {
+		frameSize = max(frameSize, 3)
+	}
+	comp := t.Encode(nil, input)
+
+	var frames [][]byte
+	start := 0
+	for pos := 0; pos < len(comp); {
+		// Width of the next code unit: escapes and extended prefixes carry a
+		// second byte, backref triples a distance and a length.
+		width := 1
+		switch {
+		case comp[pos] == fsstEscapeCode || (t.extended && comp[pos] == fsstExtendedPrefix):
+			if pos+1 < len(comp) {
+				width = 2
+			}
+		case hasMarker && comp[pos] == marker:
+			width = min(3, len(comp)-pos)
+		}
+		if pos+width-start > frameSize {
+			frames = append(frames, comp[start:pos])
+			start = pos
+		}
+		pos += width
+	}
+	if start < len(comp) {
+		frames = append(frames, comp[start:])
+	}
+	return frames
+}
@@ -0,0 +1,84 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodeChunked(t *testing.T) {
+	input := []byte(strings.Repeat("framed transport payload ", 40))
+	tbl := Train([][]byte{input})
+
+	for _, frameSize := range []int{2, 7, 16, 128, 1 << 20} {
+		frames := tbl.EncodeChunked(input, frameSize)
+		var joined []byte
+		for _, frame := range frames {
+			if len(frame) == 0 || len(frame) > frameSize {
+				t.Fatalf("frameSize=%d: frame of %d bytes", frameSize, len(frame))
+			}
+			joined = append(joined, frame...)
+		}
+		if got := tbl.Decode(nil, joined); !bytes.Equal(got, input) {
+			t.Fatalf("frameSize=%d: concatenated frames do not decode to input", frameSize)
+		}
+		// Each frame must end on a code boundary: decoding frames one by one
+		// and concatenating the outputs must also reproduce the input.
+		var piecewise []byte
+		for _, frame := range frames {
+			piecewise = tbl.AppendDecode(piecewise, frame)
+		}
+		if !bytes.Equal(piecewise, input) {
+			t.Fatalf("frameSize=%d: per-frame decoding diverges", frameSize)
+		}
+	}
+}
+
+func TestEncodeChunkedEscapes(t *testing.T) {
+	// Every byte escapes: two-byte units must never straddle a frame.
+	tbl := Train([][]byte{bytes.Repeat([]byte("unrelated"), 40)})
+	input := []byte("\x00\x01\x02\x03\x04\x05\x06\x07")
+
+	frames := tbl.EncodeChunked(input, 3)
+	var piecewise []byte
+	for _, frame := range frames {
+		piecewise = tbl.AppendDecode(piecewise, frame)
+	}
+	if !bytes.Equal(piecewise, input) {
+		t.Fatalf("escaped input diverges when decoded per frame: %q", piecewise)
+	}
+}
+
+func TestEncodeChunkedBackrefs(t *testing.T) {
+	// A period-20 input repeats beyond the 8-byte symbol limit, so encoding
+	// emits marker triples; those three-byte units must never straddle a
+	// frame. Backref frames are not independently decodable (triples copy
+	// from earlier output), so correctness is over the concatenation.
+	input := bytes.Repeat([]byte("backref period twenty"), 40)
+	tbl := Train([][]byte{input}, WithBackrefs(64))
+
+	comp := tbl.Encode(nil, input)
+	if !bytes.Contains(comp, []byte{byte(tbl.nSymbols)}) {
+		t.Skip("encoding emitted no backref triples")
+	}
+	for _, frameSize := range []int{2, 3, 7, 16, 128} {
+		frames := tbl.EncodeChunked(input, frameSize)
+		var joined []byte
+		for _, frame := range frames {
+			if len(frame) == 0 || len(frame) > max(frameSize, 3) {
+				t.Fatalf("frameSize=%d: frame of %d bytes", frameSize, len(frame))
+			}
+			joined = append(joined, frame...)
+		}
+		if got := tbl.Decode(nil, joined); !bytes.Equal(got, input) {
+			t.Fatalf("frameSize=%d: concatenated frames do not decode to input", frameSize)
+		}
+	}
+}
+
+func TestEncodeChunkedEmpty(t *testing.T) {
+	tbl := Train([][]byte{[]byte("abc")})
+	if frames := tbl.EncodeChunked(nil, 16); frames != nil {
+		t.Fatalf("expected no frames for empty input, got %d", len(frames))
+	}
+}
@@ -0,0 +1,46 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSetChunkSizeOutputInvariant(t *testing.T) {
+	data, err := os.ReadFile("testdata/en_mobydick.txt")
+	if err != nil {
+		t.Skip("corpus not available")
+	}
+	data = data[:1<<16]
+	tbl := Train([][]byte{data})
+	want := tbl.Encode(nil, data)
+
+	for _, size := range []int{8, 64, 511, 1023, 2047, 4096, 1 << 16} {
+		if err := tbl.SetChunkSize(size); err != nil {
+			t.Fatalf("SetChunkSize(%d): %v", size, err)
+		}
+		got := tbl.Encode(nil, data)
+		if !bytes.Equal(got, want) {
+			t.Fatalf("chunk size %d changed encode output (%d vs %d bytes)",
+				size, len(got), len(want))
+		}
+		if n := tbl.EncodedLen(data); n != len(want) {
+			t.Fatalf("chunk size %d: EncodedLen %d, want %d", size, n, len(want))
+		}
+		if dec := tbl.Decode(nil, got); !bytes.Equal(dec, data) {
+			t.Fatalf("chunk size %d: roundtrip failed", size)
+		}
+	}
+}
+
+func TestSetChunkSizeValidation(t *testing.T) {
+	tbl := Train([][]byte{[]byte("chunk size validation")})
+	for _, bad := range []int{-1, 0, 7, MaxEncodeLen} {
+		if err := tbl.SetChunkSize(bad); err != ErrBadChunkSize {
+			t.Fatalf("SetChunkSize(%d): got %v, want ErrBadChunkSize", bad, err)
+		}
+	}
+	if err := tbl.SetChunkSize(1023); err != nil {
+		t.Fatalf("SetChunkSize(1023): %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package fsst
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestCloneIndependentEncoding(t *testing.T) {
+	input := []byte("concurrent encoders need independent scratch buffers")
+	table := Train([][]byte{input})
+	want := table.Encode(nil, input)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		clone := table.Clone()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				got := clone.Encode(nil, input)
+				if !bytes.Equal(got, want) {
+					t.Errorf("clone encoding differs from original")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWarmDecoderConcurrentDecode(t *testing.T) {
+	input := []byte("warmed decoders are safe to share across goroutines")
+	table := Train([][]byte{input})
+	compressed := table.Encode(nil, input)
+	table.WarmDecoder()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				got := table.Decode(nil, compressed)
+				if !bytes.Equal(got, input) {
+					t.Errorf("concurrent decode mismatch")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
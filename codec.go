@@ -0,0 +1,24 @@
+package fsst
+
+// Codec wraps a Table with caller-provided conversions between a domain type
+// and its byte representation, so typed values compress and decompress in one
+// call instead of marshalling at every call site. It is a thin layer over
+// Encode/Decode: no extra copies beyond what Marshal and Unmarshal make.
+type Codec[T any] struct {
+	Table     *Table
+	Marshal   func(T) []byte
+	Unmarshal func([]byte) T
+}
+
+// Encode marshals v and compresses the result. buf follows the same reuse
+// semantics as Table.Encode.
+func (c *Codec[T]) Encode(buf []byte, v T) []byte {
+	return c.Table.Encode(buf, c.Marshal(v))
+}
+
+// Decode decompresses src and unmarshals the result back into the domain
+// type. Each call decodes into a fresh buffer, so Unmarshal may retain the
+// bytes it is handed.
+func (c *Codec[T]) Decode(src []byte) T {
+	return c.Unmarshal(c.Table.Decode(nil, src))
+}
@@ -0,0 +1,59 @@
+package fsst
+
+// Compact repacks the code space after manual symbol surgery: it drops
+// invalid or cleared entries, recounts lenHisto, re-runs finalize to restore
+// the fast-path ordering invariants (unique-prefix 2-byte symbols first,
+// suffixLim and byteLim consistent), and invalidates the lazy caches so the
+// next Encode or Decode rebuilds its lookup structures from the new layout.
+// Codes are reassigned, so previously encoded data must be decoded with the
+// old table. State defined against the old layout cannot survive that: macro
+// codes, the order-preserving trie, and a frequency code remap are all
+// dropped, turning an ordered or remapped table into a plain one (re-run
+// AssignCodesByFrequency afterwards to restore a remap). Backrefs derive
+// their marker from the current symbol count and carry over, unless the
+// repack fills all 255 codes and leaves no spare for it.
+func (t *Table) Compact() {
+	var hist [8]uint16
+	packed := 0
+	for i := 0; i < int(t.nSymbols); i++ {
+		sym := t.symbols[i]
+		length := sym.length()
+		if length < 1 || length > 8 {
+			continue // cleared or corrupted slot: reclaim its code
+		}
+		// finalize consumes the training-slot view at fsstCodeBase+i.
+		t.symbols[int(fsstCodeBase)+packed] = sym
+		hist[length-1]++
+		packed++
+	}
+	t.nSymbols = uint16(packed)
+	t.lenHisto = hist
+
+	if t.hasMacros {
+		t.hasMacros = false
+		t.macroBase = 0
+		t.macros = nil
+		t.macroLookup = nil
+	}
+	if t.ordered {
+		// The trie maps prefixes to codes from the old buildOrdered layout;
+		// encoding through it after finalize reassigns codes would corrupt
+		// output silently. Order preservation is gone either way once codes
+		// move, so drop to a plain table.
+		t.ordered = false
+		t.ordEscMin = 0
+		t.ordLeaves = nil
+		t.ordRoot = nil
+	}
+	if t.freqRemap != nil {
+		// The permutation is keyed by the old canonical codes.
+		t.freqRemap = nil
+		t.freqInv = nil
+	}
+	if t.backrefWindow > 0 && int(t.nSymbols) >= fsstMaxSymbols {
+		t.backrefWindow = 0 // no spare code left for the marker
+	}
+
+	t.finalize()
+	t.invalidateCaches()
+}
@@ -0,0 +1,103 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	corpus := []byte(strings.Repeat("compact the code space after surgery ", 100))
+	tbl := Train([][]byte{corpus})
+
+	// Compacting an untouched table keeps it fully functional.
+	before := tbl.nSymbols
+	tbl.Compact()
+	if tbl.nSymbols != before {
+		t.Fatalf("compact changed symbol count on a clean table: %d -> %d", before, tbl.nSymbols)
+	}
+	comp := tbl.Encode(nil, corpus)
+	if got := tbl.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("roundtrip failed after compacting a clean table")
+	}
+
+	// Clear a few multi-byte entries to simulate eviction, then compact:
+	// the gaps must be reclaimed and the table must still encode correctly.
+	cleared := 0
+	for i := 0; i < int(tbl.nSymbols) && cleared < 3; i++ {
+		if tbl.symbols[i].length() >= 2 {
+			tbl.symbols[i] = symbol{}
+			cleared++
+		}
+	}
+	tbl.Compact()
+	if int(tbl.nSymbols) != int(before)-cleared {
+		t.Fatalf("compact kept %d symbols, want %d", tbl.nSymbols, int(before)-cleared)
+	}
+	var histoSum int
+	for _, c := range tbl.lenHisto {
+		histoSum += int(c)
+	}
+	if histoSum != int(tbl.nSymbols) {
+		t.Fatalf("lenHisto sums to %d, nSymbols is %d", histoSum, tbl.nSymbols)
+	}
+	comp = tbl.Encode(nil, corpus)
+	if got := tbl.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("roundtrip failed after compacting gaps")
+	}
+
+	// The serialized form must also hold together after compaction.
+	blob, err := tbl.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary after compact: %v", err)
+	}
+	var restored Table
+	if err := restored.UnmarshalBinary(blob); err != nil {
+		t.Fatalf("UnmarshalBinary after compact: %v", err)
+	}
+	if got := restored.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("restored table failed to decode")
+	}
+}
+
+func TestCompactDropsLayoutBoundState(t *testing.T) {
+	corpus := []byte(strings.Repeat("banana gamma apple zeta ", 100))
+
+	// Ordered: the trie speaks the old code layout, so Compact must drop it
+	// rather than let Encode walk stale codes into corrupt output.
+	ordered := TrainOrderPreserving([][]byte{corpus})
+	ordered.Compact()
+	if ordered.ordered || ordered.ordRoot != nil || ordered.ordLeaves != nil || ordered.ordEscMin != 0 {
+		t.Fatal("compact kept order-preserving state")
+	}
+	comp := ordered.Encode(nil, corpus)
+	if got := ordered.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("roundtrip failed after compacting an ordered table: got %q", got)
+	}
+
+	// Frequency remap: keyed by the old canonical codes, likewise dropped.
+	remapped := Train([][]byte{corpus})
+	if err := remapped.AssignCodesByFrequency([][]byte{corpus}); err != nil {
+		t.Fatal(err)
+	}
+	remapped.Compact()
+	if remapped.freqRemap != nil || remapped.freqInv != nil {
+		t.Fatal("compact kept a stale frequency remap")
+	}
+	comp = remapped.Encode(nil, corpus)
+	if got := remapped.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("roundtrip failed after compacting a remapped table")
+	}
+
+	// Backrefs derive their marker from the live symbol count and survive.
+	backref := Train([][]byte{corpus}, WithBackrefs(64))
+	window := backref.backrefWindow
+	backref.Compact()
+	if backref.backrefWindow != window {
+		t.Fatalf("compact changed backref window: %d -> %d", window, backref.backrefWindow)
+	}
+	comp = backref.Encode(nil, corpus)
+	if got := backref.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("roundtrip failed after compacting a backref table")
+	}
+}
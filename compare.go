@@ -0,0 +1,67 @@
+package fsst
+
+import "time"
+
+// CodecReport summarizes measured FSST performance over a set of inputs, for
+// comparing against other codecs measured externally on the same data. All
+// throughput figures are bytes of original input per second.
+type CodecReport struct {
+	InputBytes      int           // total original bytes measured
+	EncodedBytes    int           // total encoded bytes produced
+	Ratio           float64       // EncodedBytes / InputBytes; below 1 means the data compresses
+	TrainTime       time.Duration // wall time spent training the table
+	EncodeTime      time.Duration // wall time spent encoding all inputs
+	DecodeTime      time.Duration // wall time spent decoding all encoded outputs
+	TrainBytesPerS  float64       // InputBytes / TrainTime
+	EncodeBytesPerS float64       // InputBytes / EncodeTime
+	DecodeBytesPerS float64       // InputBytes / DecodeTime
+}
+
+// CompareCodecs trains a table on inputs, encodes and decodes every input
+// once, and returns the measured ratio and throughput. It is deliberately
+// dependency-free: run another codec over the same inputs yourself and set
+// the reports side by side. Timings are single-shot wall clock, so prefer
+// inputs totalling at least a few megabytes for stable numbers.
+func CompareCodecs(inputs [][]byte) CodecReport {
+	var report CodecReport
+	for i := range inputs {
+		report.InputBytes += len(inputs[i])
+	}
+
+	start := time.Now()
+	table := Train(inputs)
+	report.TrainTime = time.Since(start)
+
+	encoded := make([][]byte, len(inputs))
+	start = time.Now()
+	for i := range inputs {
+		encoded[i] = table.Encode(nil, inputs[i])
+	}
+	report.EncodeTime = time.Since(start)
+	for i := range encoded {
+		report.EncodedBytes += len(encoded[i])
+	}
+
+	var buf []byte
+	start = time.Now()
+	for i := range encoded {
+		buf = table.Decode(buf, encoded[i])
+	}
+	report.DecodeTime = time.Since(start)
+
+	if report.InputBytes > 0 {
+		report.Ratio = float64(report.EncodedBytes) / float64(report.InputBytes)
+	}
+	report.TrainBytesPerS = bytesPerSecond(report.InputBytes, report.TrainTime)
+	report.EncodeBytesPerS = bytesPerSecond(report.InputBytes, report.EncodeTime)
+	report.DecodeBytesPerS = bytesPerSecond(report.InputBytes, report.DecodeTime)
+	return report
+}
+
+// bytesPerSecond guards against a zero elapsed time on very small inputs.
+func bytesPerSecond(n int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(n) / elapsed.Seconds()
+}
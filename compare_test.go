@@ -0,0 +1,48 @@
+package fsst
+
+import "testing"
+
+func TestCompareCodecs(t *testing.T) {
+	var inputs [][]byte
+	for i := 0; i < 500; i++ {
+		inputs = append(inputs, []byte("http://example.com/path/to/resource"))
+	}
+	report := CompareCodecs(inputs)
+
+	if report.InputBytes != 500*len(inputs[0]) {
+		t.Errorf("InputBytes = %d, want %d", report.InputBytes, 500*len(inputs[0]))
+	}
+	if report.Ratio <= 0 || report.Ratio >= 1 {
+		t.Errorf("Ratio = %f, want a compressing ratio in (0, 1)", report.Ratio)
+	}
+	if report.TrainTime <= 0 || report.EncodeTime <= 0 || report.DecodeTime <= 0 {
+		t.Error("expected positive timings")
+	}
+	if report.EncodeBytesPerS <= 0 || report.DecodeBytesPerS <= 0 {
+		t.Error("expected positive throughput figures")
+	}
+}
+
+func TestCompareCodecsEmpty(t *testing.T) {
+	report := CompareCodecs(nil)
+	if report.InputBytes != 0 || report.Ratio != 0 {
+		t.Errorf("unexpected report for empty inputs: %+v", report)
+	}
+}
+
+func TestCompareCodecsMatchesEncode(t *testing.T) {
+	inputs := [][]byte{
+		[]byte("hello world hello world hello world"),
+		[]byte("hello world again and again and again"),
+	}
+	report := CompareCodecs(inputs)
+
+	table := Train(inputs)
+	var want int
+	for _, input := range inputs {
+		want += len(table.Encode(nil, input))
+	}
+	if report.EncodedBytes != want {
+		t.Errorf("EncodedBytes = %d, want %d", report.EncodedBytes, want)
+	}
+}
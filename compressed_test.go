@@ -0,0 +1,89 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWriteToCompressedRoundtrip(t *testing.T) {
+	data, err := os.ReadFile("testdata/art_of_war.txt")
+	if err != nil {
+		t.Skipf("missing corpus: %v", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	bLines := make([][]byte, len(lines))
+	for i := range lines {
+		bLines[i] = []byte(lines[i])
+	}
+	tbl := Train(bLines)
+
+	var plain, compressed bytes.Buffer
+	if _, err := tbl.WriteTo(&plain); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if _, err := tbl.WriteToCompressed(&compressed); err != nil {
+		t.Fatalf("WriteToCompressed: %v", err)
+	}
+	if compressed.Len() >= plain.Len() {
+		t.Logf("compressed table not smaller: %d >= %d", compressed.Len(), plain.Len())
+	}
+
+	var tbl2 Table
+	if _, err := tbl2.ReadFromCompressed(&compressed); err != nil {
+		t.Fatalf("ReadFromCompressed: %v", err)
+	}
+	for i := range bLines {
+		comp1 := tbl.EncodeAll(bLines[i])
+		comp2 := tbl2.EncodeAll(bLines[i])
+		if !bytes.Equal(comp1, comp2) {
+			t.Fatalf("compressed-format roundtrip changed encoding at line %d", i)
+		}
+	}
+}
+
+func TestReadFromCompressedRejectsPlain(t *testing.T) {
+	tbl := Train([][]byte{[]byte("hello world")})
+	var plain bytes.Buffer
+	if _, err := tbl.WriteTo(&plain); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFromCompressed(&plain); err != ErrBadFormat {
+		t.Fatalf("expected ErrBadFormat, got %v", err)
+	}
+}
+
+func TestReadFromRejectsCompressed(t *testing.T) {
+	// The converse of TestReadFromCompressedRejectsPlain: ReadFrom must not
+	// let the compressed format flag fall through to the plain parse and
+	// return a silently corrupt table.
+	tbl := Train([][]byte{[]byte(strings.Repeat("cross-format reads rejected ", 50))})
+	var compressed bytes.Buffer
+	if _, err := tbl.WriteToCompressed(&compressed); err != nil {
+		t.Fatalf("WriteToCompressed: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFrom(&compressed); err != ErrBadFormat {
+		t.Fatalf("expected ErrBadFormat, got %v", err)
+	}
+}
+
+func TestReadFromCompressedRejectsHistoMismatch(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("histogram validation ", 50))})
+	var buf bytes.Buffer
+	if _, err := tbl.WriteToCompressed(&buf); err != nil {
+		t.Fatalf("WriteToCompressed: %v", err)
+	}
+
+	// Same tamper as TestReadFromRejectsHistoMismatch: a histogram claiming
+	// more symbols than the header would overrun the code schedule.
+	blob := buf.Bytes()
+	blob[8] = 255 // lenHisto[0] lives right after the 8-byte version word
+
+	var tampered Table
+	if _, err := tampered.ReadFromCompressed(bytes.NewReader(blob)); err != ErrBadFormat {
+		t.Fatalf("tampered histogram accepted: err=%v, want ErrBadFormat", err)
+	}
+}
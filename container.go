@@ -0,0 +1,128 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// ErrBadRecord indicates a container record whose CRC does not match its
+// payload, or whose frame is truncated.
+var ErrBadRecord = errors.New("fsst: container record corrupt")
+
+// containerCRC is the CRC-32 (Castagnoli) table shared by writer and reader.
+var containerCRC = crc32.MakeTable(crc32.Castagnoli)
+
+// ContainerWriter writes a self-contained archive: the serialized table once
+// as a header, then framed encoded records. Each record is a uvarint payload
+// length, the encoded payload, and a CRC-32 (Castagnoli) of the payload, so
+// a reader can verify integrity record by record. The resulting stream is
+// decodable from its own bytes alone — no table is shipped separately.
+type ContainerWriter struct {
+	w     io.Writer
+	table *Table
+	buf   []byte
+}
+
+// NewContainerWriter writes t's serialized form to w and returns a writer
+// appending framed records encoded with t.
+func NewContainerWriter(w io.Writer, t *Table) (*ContainerWriter, error) {
+	if _, err := t.WriteTo(w); err != nil {
+		return nil, err
+	}
+	return &ContainerWriter{w: w, table: t}, nil
+}
+
+// WriteRecord encodes record and appends it as one verifiable frame.
+func (c *ContainerWriter) WriteRecord(record []byte) error {
+	c.buf = c.table.Encode(c.buf, record)
+
+	var hdr [binary.MaxVarintLen64]byte
+	hdrLen := binary.PutUvarint(hdr[:], uint64(len(c.buf)))
+	if _, err := c.w.Write(hdr[:hdrLen]); err != nil {
+		return err
+	}
+	if _, err := c.w.Write(c.buf); err != nil {
+		return err
+	}
+	var crc [4]byte
+	binary.LittleEndian.PutUint32(crc[:], crc32.Checksum(c.buf, containerCRC))
+	_, err := c.w.Write(crc[:])
+	return err
+}
+
+// ContainerReader iterates the records of a container produced by
+// ContainerWriter, decoding with the table read from the header.
+type ContainerReader struct {
+	r     *byteReader
+	table Table
+}
+
+// NewContainerReader reads the table header from r and returns a reader
+// positioned at the first record.
+func NewContainerReader(r io.Reader) (*ContainerReader, error) {
+	br := &byteReader{r: r}
+	cr := &ContainerReader{r: br}
+	if _, err := cr.table.ReadFrom(br); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// Table returns the table carried in the container header.
+func (c *ContainerReader) Table() *Table {
+	return &c.table
+}
+
+// Next reads, verifies, and decodes the next record. It returns io.EOF once
+// the container is exhausted and ErrBadRecord when a frame is truncated or
+// fails its CRC. The returned slice is freshly allocated.
+func (c *ContainerReader) Next() ([]byte, error) {
+	payloadLen, err := binary.ReadUvarint(c.r)
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, ErrBadRecord
+		}
+		return nil, err
+	}
+	// The length comes from the stream, so don't allocate it up front: a
+	// corrupt header claiming gigabytes (or 2^63, which would panic makeslice)
+	// must fail at the stream's real end having bought only what was read.
+	// Grow the payload in bounded steps instead.
+	const step = 64 << 10
+	var payload []byte
+	for remaining := payloadLen; remaining > 0; {
+		n := int(min(remaining, step))
+		start := len(payload)
+		payload = append(payload, make([]byte, n)...)
+		if _, err := io.ReadFull(c.r, payload[start:]); err != nil {
+			return nil, ErrBadRecord
+		}
+		remaining -= uint64(n)
+	}
+	var crc [4]byte
+	if _, err := io.ReadFull(c.r, crc[:]); err != nil {
+		return nil, ErrBadRecord
+	}
+	if crc32.Checksum(payload, containerCRC) != binary.LittleEndian.Uint32(crc[:]) {
+		return nil, ErrBadRecord
+	}
+	return c.table.Decode(nil, payload), nil
+}
+
+// byteReader adds the io.ByteReader needed by binary.ReadUvarint to a plain
+// io.Reader without buffering ahead of the record boundary.
+type byteReader struct {
+	r io.Reader
+}
+
+func (b *byteReader) Read(p []byte) (int, error) { return b.r.Read(p) }
+
+func (b *byteReader) ReadByte() (byte, error) {
+	var one [1]byte
+	if _, err := io.ReadFull(b.r, one[:]); err != nil {
+		return 0, err
+	}
+	return one[0], nil
+}
@@ -2,7 +2,7 @@ package fsst
 
 // counters tracks symbol and symbol-pair frequencies during training.
 //
-// Memory layout optimizes for space (512 codes × 5 training rounds = lots of counting):
+// Memory layout optimizes for space (fsstCodeMax codes × 5 training rounds = lots of counting):
 //   - Single-symbol counts: split into high/low bytes (16-bit range: 0-65535)
 //   - Pair counts: nibble-packed high byte (4-bit) + full low byte (12-bit range: 0-4095)
 //   - Sparse pair tracking: list of [code1, code2] pairs with non-zero counts for fast iteration
@@ -11,7 +11,7 @@ package fsst
 // but this happens one cycle early (at 0→1 transition). The getNext methods compensate
 // by decrementing high when both high and low are non-zero.
 //
-// Total size: ~512KB (acceptable for training phase, discarded after).
+// Total size: ~1.5MB (acceptable for training phase, discarded after).
 type counters struct {
 	singleHigh [fsstCodeMax]uint8                  // High byte of single-symbol counts
 	singleLow  [fsstCodeMax]uint8                  // Low byte of single-symbol counts
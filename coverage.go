@@ -0,0 +1,81 @@
+package fsst
+
+// CoverageReport breaks down how input bytes were matched during encoding:
+// by learned multi-byte symbols, by learned single-byte symbols, or by escape
+// sequences for bytes the table has no symbol for. High escape fractions mean
+// the table was trained on data that no longer resembles the encode corpus.
+type CoverageReport struct {
+	TotalBytes      int     // input bytes examined
+	MultiByteBytes  int     // bytes covered by symbols of length 2-8
+	SingleByteBytes int     // bytes covered by learned 1-byte symbols
+	EscapeBytes     int     // bytes emitted as escape sequences
+	MultiByteFrac   float64 // MultiByteBytes / TotalBytes
+	SingleByteFrac  float64 // SingleByteBytes / TotalBytes
+	EscapeFrac      float64 // EscapeBytes / TotalBytes
+}
+
+// Coverage encodes each input once and classifies every input byte by the
+// kind of code that covered it, aggregated over all inputs. Use it to judge
+// whether the training corpus was representative: a dominant escape fraction
+// is the signal to retrain.
+func (t *Table) Coverage(inputs [][]byte) CoverageReport {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	var report CoverageReport
+	var comp []byte
+	for i := range inputs {
+		report.TotalBytes += len(inputs[i])
+		comp = t.Encode(comp[:0], inputs[i])
+		for pos := 0; pos < len(comp); {
+			code := uint16(comp[pos])
+			pos++
+			switch {
+			case comp[pos-1] == fsstEscapeCode:
+				report.EscapeBytes++
+				pos++ // skip the literal byte
+			case t.extended:
+				if code == fsstExtendedPrefix && pos < len(comp) {
+					code = fsstExtendedPrefix + uint16(comp[pos])
+					pos++
+				}
+				report.tally(int(t.decLenExt[code]))
+			case t.hasMacros && code >= t.macroBase:
+				if idx := code - t.macroBase; int(idx) < len(t.macros) {
+					for _, c := range t.macros[idx] {
+						report.tally(int(t.decLen[c]))
+					}
+				}
+			case t.backrefWindow > 0 && code == t.nSymbols:
+				// Back-referenced bytes count as multi-byte coverage: the
+				// table matched them, just via a copy rather than a symbol.
+				if pos+1 < len(comp) {
+					report.MultiByteBytes += int(comp[pos+1])
+				}
+				pos += 2
+			default:
+				report.tally(int(t.decLen[code]))
+			}
+		}
+	}
+	if report.TotalBytes > 0 {
+		total := float64(report.TotalBytes)
+		report.MultiByteFrac = float64(report.MultiByteBytes) / total
+		report.SingleByteFrac = float64(report.SingleByteBytes) / total
+		report.EscapeFrac = float64(report.EscapeBytes) / total
+	}
+	return report
+}
+
+// tally attributes length input bytes to the symbol-length bucket they were
+// matched by.
+func (r *CoverageReport) tally(length int) {
+	if length > 1 {
+		r.MultiByteBytes += length
+	} else {
+		r.SingleByteBytes += length
+	}
+}
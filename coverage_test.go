@@ -0,0 +1,47 @@
+package fsst
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestCoverageRepresentative(t *testing.T) {
+	var inputs [][]byte
+	for i := 0; i < 200; i++ {
+		inputs = append(inputs, []byte("GET /api/v1/users HTTP/1.1"))
+	}
+	table := Train(inputs)
+	report := table.Coverage(inputs)
+
+	if report.TotalBytes != 200*len(inputs[0]) {
+		t.Errorf("TotalBytes = %d, want %d", report.TotalBytes, 200*len(inputs[0]))
+	}
+	if sum := report.MultiByteBytes + report.SingleByteBytes + report.EscapeBytes; sum != report.TotalBytes {
+		t.Errorf("byte buckets sum to %d, want %d", sum, report.TotalBytes)
+	}
+	if report.MultiByteFrac < 0.5 {
+		t.Errorf("MultiByteFrac = %f, expected multi-byte symbols to dominate on training data", report.MultiByteFrac)
+	}
+	fracSum := report.MultiByteFrac + report.SingleByteFrac + report.EscapeFrac
+	if math.Abs(fracSum-1) > 1e-9 {
+		t.Errorf("fractions sum to %f, want 1", fracSum)
+	}
+}
+
+func TestCoverageStaleTable(t *testing.T) {
+	table := Train([][]byte{bytes.Repeat([]byte("aaaa"), 100)})
+	report := table.Coverage([][]byte{[]byte("ZYXWVUTSRQ0123456789")})
+
+	if report.EscapeFrac < 0.5 {
+		t.Errorf("EscapeFrac = %f, expected escapes to dominate on unrelated data", report.EscapeFrac)
+	}
+}
+
+func TestCoverageEmpty(t *testing.T) {
+	table := Train([][]byte{[]byte("hello")})
+	report := table.Coverage(nil)
+	if report.TotalBytes != 0 || report.EscapeFrac != 0 {
+		t.Errorf("unexpected report for no inputs: %+v", report)
+	}
+}
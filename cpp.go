@@ -0,0 +1,79 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// The reference C++ FSST library (cwida/fsst, used by DuckDB among others)
+// serializes tables with fsst_export in a layout close to, but not identical
+// to, ours: the version word packs suffixLim<<24 | terminator<<16 |
+// nSymbols<<8 | endian-marker, followed by a zeroTerminated byte, 8 lenHisto
+// bytes, and the symbol bytes. Symbols appear in code order: lengths 2..8
+// first, then 1-byte symbols, with an optional implicit empty symbol at code
+// 0 when zeroTerminated is set.
+
+// ReadFromCPP parses a table serialized by the reference C++ library's
+// fsst_export and returns a Table able to decode data compressed by it.
+// Only the decode-relevant fields (symbol values and lengths) are mapped
+// faithfully; encoding with the returned table works but may not byte-match
+// the C++ encoder's output.
+func ReadFromCPP(r io.Reader) (*Table, error) {
+	var hdr [17]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	ver := binary.LittleEndian.Uint64(hdr[:8])
+	if ver>>32 != fsstVersion {
+		return nil, ErrBadVersion
+	}
+
+	t := newTable()
+	t.suffixLim = uint16((ver >> 24) & fsstMask8)
+	t.nSymbols = uint16((ver >> 8) & fsstMask8)
+	zeroTerminated := hdr[8]&1 != 0
+
+	var lenHisto [8]int
+	for i := range 8 {
+		lenHisto[i] = int(hdr[9+i])
+		t.lenHisto[i] = uint16(hdr[9+i])
+	}
+
+	code := 0
+	if zeroTerminated {
+		// Code 0 is the implicit zero byte used as string terminator; it is
+		// counted in lenHisto[0] but not stored in the blob.
+		t.symbols[0] = newSymbolFromByte(0, packCodeLength(0, 1))
+		lenHisto[0]--
+		code = 1
+	}
+	// Symbol schedule mirrors fsst_import: slot l&7 holds symbols of length
+	// (l&7)+1, so l=1..7 reads lengths 2..8 and l=8 wraps around to the
+	// 1-byte symbols stored last.
+	for l := 1; l <= 8; l++ {
+		symbolLength := (l & 7) + 1
+		for i := 0; i < lenHisto[l&7]; i++ {
+			if code >= fsstMaxSymbols {
+				return nil, ErrBadFormat
+			}
+			var b8 [8]byte
+			if _, err := io.ReadFull(r, b8[:symbolLength]); err != nil {
+				return nil, err
+			}
+			var symbolValue uint64
+			for byteIdx := range symbolLength {
+				symbolValue |= uint64(b8[byteIdx]) << (8 * byteIdx)
+			}
+			sym := symbol{val: symbolValue}
+			sym.setCodeLen(uint32(code), uint32(symbolLength))
+			t.symbols[code] = sym
+			code++
+		}
+	}
+	if code != int(t.nSymbols) {
+		return nil, ErrBadFormat
+	}
+	t.accelReady = false
+	t.uniformLen = computeUniformLen(t.lenHisto)
+	return t, nil
+}
@@ -0,0 +1,64 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testdata/cpp_table.fsst is a table in the reference fsst_export layout with
+// three symbols: code 0 = "ab", code 1 = "xyz", code 2 = "q".
+func TestReadFromCPPGolden(t *testing.T) {
+	blob, err := os.ReadFile(filepath.Join("testdata", "cpp_table.fsst"))
+	if err != nil {
+		t.Fatalf("read golden blob: %v", err)
+	}
+	tbl, err := ReadFromCPP(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatalf("ReadFromCPP: %v", err)
+	}
+	if tbl.NumSymbols() != 3 {
+		t.Fatalf("NumSymbols = %d, want 3", tbl.NumSymbols())
+	}
+	want := [][]byte{[]byte("ab"), []byte("xyz"), []byte("q")}
+	for code, sym := range tbl.Symbols() {
+		if !bytes.Equal(sym, want[code]) {
+			t.Errorf("code %d = %q, want %q", code, sym, want[code])
+		}
+	}
+
+	// A C++-encoded stream using these codes decodes as expected, including
+	// an escaped literal.
+	compressed := []byte{0, 1, 2, fsstEscapeCode, '!'}
+	if got := tbl.Decode(nil, compressed); !bytes.Equal(got, []byte("abxyzq!")) {
+		t.Fatalf("decode = %q, want %q", got, "abxyzq!")
+	}
+}
+
+func TestReadFromCPPZeroTerminated(t *testing.T) {
+	blob, err := os.ReadFile(filepath.Join("testdata", "cpp_table.fsst"))
+	if err != nil {
+		t.Fatalf("read golden blob: %v", err)
+	}
+	// Flip on zeroTerminated and bump the 1-byte count plus nSymbols: code 0
+	// becomes the implicit zero symbol, the stored symbols shift up by one.
+	blob = bytes.Clone(blob)
+	blob[1] = 4 // nSymbols in the version word
+	blob[8] = 1
+	blob[9] = 2 // lenHisto[0] includes the implicit terminator symbol
+	tbl, err := ReadFromCPP(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatalf("ReadFromCPP: %v", err)
+	}
+	if got := tbl.Decode(nil, []byte{0, 1, 2, 3}); !bytes.Equal(got, []byte("\x00abxyzq")) {
+		t.Fatalf("decode = %q, want %q", got, "\x00abxyzq")
+	}
+}
+
+func TestReadFromCPPBadVersion(t *testing.T) {
+	blob := make([]byte, 23)
+	if _, err := ReadFromCPP(bytes.NewReader(blob)); err != ErrBadVersion {
+		t.Fatalf("err = %v, want ErrBadVersion", err)
+	}
+}
@@ -0,0 +1,35 @@
+package fsst
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestDecodeWithPool(t *testing.T) {
+	input := bytes.Repeat([]byte("pooled decode input "), 50)
+	tbl := Train([][]byte{input})
+	comp := tbl.EncodeAll(input)
+
+	var pool sync.Pool
+	// Warm up: first decode grows a buffer, return it to the pool.
+	got := tbl.DecodeWithPool(&pool, comp)
+	if !bytes.Equal(got, input) {
+		t.Fatalf("pooled decode mismatch")
+	}
+	pool.Put(got)
+
+	// Steady state: same-sized decodes should not allocate.
+	allocs := testing.AllocsPerRun(100, func() {
+		out := tbl.DecodeWithPool(&pool, comp)
+		pool.Put(out)
+	})
+	if allocs > 1 {
+		t.Fatalf("expected near-zero allocations in steady state, got %f", allocs)
+	}
+
+	got = tbl.DecodeWithPool(&pool, comp)
+	if !bytes.Equal(got, input) {
+		t.Fatalf("pooled decode mismatch after reuse")
+	}
+}
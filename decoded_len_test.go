@@ -0,0 +1,42 @@
+package fsst
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodedLen(t *testing.T) {
+	data, err := os.ReadFile("testdata/art_of_war.txt")
+	if err != nil {
+		t.Skipf("missing corpus: %v", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	bLines := make([][]byte, len(lines))
+	for i := range lines {
+		bLines[i] = []byte(lines[i])
+	}
+	tbl := Train(bLines)
+	for i := range bLines {
+		comp := tbl.EncodeAll(bLines[i])
+		if got, want := tbl.DecodedLen(comp), len(bLines[i]); got != want {
+			t.Fatalf("DecodedLen mismatch at line %d: got %d, want %d", i, got, want)
+		}
+	}
+
+	// Truncated escape at end of stream contributes nothing.
+	if got := tbl.DecodedLen([]byte{fsstEscapeCode}); got != 0 {
+		t.Fatalf("trailing escape: got %d, want 0", got)
+	}
+}
+
+func TestDecodedLenExtended(t *testing.T) {
+	inputs := diverseCorpus()
+	tbl := Train(inputs, WithExtendedCodes(true))
+	for i := range inputs {
+		comp := tbl.EncodeAll(inputs[i])
+		if got, want := tbl.DecodedLen(comp), len(inputs[i]); got != want {
+			t.Fatalf("extended DecodedLen mismatch at input %d: got %d, want %d", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package fsst
+
+import "encoding/binary"
+
+// DecoderOnly is a compact decode-side view of a Table. A full Table carries
+// ~130KB of encoder lookup structures (shortCodes alone is 128KB); a
+// decode-only service caching many tables needs none of that. DecoderOnly
+// holds just the code→symbol arrays — a few KB — and decodes identically to
+// Table.Decode.
+type DecoderOnly struct {
+	decLen        []byte   // code → symbol length
+	decSymbol     []uint64 // code → symbol value (little-endian)
+	extended      bool
+	macroBase     uint16
+	macros        [][2]uint16
+	backrefWindow uint8
+}
+
+// Decoder returns a compact decode-only view of the table. The returned
+// value shares no state with t and remains valid if t is discarded, which is
+// the point: drop the Table and keep only this.
+func (t *Table) Decoder() *DecoderOnly {
+	d := &DecoderOnly{extended: t.extended}
+	if t.extended {
+		t.ensureDecoderExtended()
+		d.decLen = append([]byte(nil), t.decLenExt...)
+		d.decSymbol = append([]uint64(nil), t.decSymbolExt...)
+		return d
+	}
+	t.ensureDecoder()
+	d.decLen = append([]byte(nil), t.decLen[:t.nSymbols]...)
+	d.decSymbol = append([]uint64(nil), t.decSymbol[:t.nSymbols]...)
+	if t.hasMacros {
+		d.macroBase = t.macroBase
+		d.macros = append([][2]uint16(nil), t.macros...)
+	}
+	d.backrefWindow = t.backrefWindow
+	return d
+}
+
+// Decode decompresses src, optionally reusing buf for output, with the same
+// semantics as Table.Decode.
+func (d *DecoderOnly) Decode(buf, src []byte) []byte {
+	if buf == nil {
+		buf = make([]byte, 0, len(src)*4+8)
+	} else {
+		buf = buf[:0] // Reset length but keep capacity
+	}
+
+	var tmp [8]byte
+	for srcPos := 0; srcPos < len(src); {
+		code := uint16(src[srcPos])
+		srcPos++
+		if code == fsstEscapeCode {
+			if srcPos >= len(src) {
+				return buf
+			}
+			buf = append(buf, src[srcPos])
+			srcPos++
+			continue
+		}
+		if d.extended && code == fsstExtendedPrefix {
+			if srcPos >= len(src) {
+				return buf
+			}
+			code = fsstExtendedPrefix + uint16(src[srcPos])
+			srcPos++
+		}
+		if d.backrefWindow > 0 && code == uint16(len(d.decLen)) {
+			// Back-reference triple: the marker is the first code past the
+			// learned symbols, so it equals the decode array length here.
+			if srcPos+1 >= len(src) {
+				return buf
+			}
+			dist := int(src[srcPos])
+			length := int(src[srcPos+1])
+			srcPos += 2
+			if dist < 1 || dist > len(buf) {
+				continue
+			}
+			for range length {
+				buf = append(buf, buf[len(buf)-dist])
+			}
+			continue
+		}
+		if d.macros != nil && code >= d.macroBase {
+			if idx := code - d.macroBase; int(idx) < len(d.macros) {
+				for _, c := range d.macros[idx] {
+					binary.LittleEndian.PutUint64(tmp[:], d.decSymbol[c])
+					buf = append(buf, tmp[:d.decLen[c]]...)
+				}
+			}
+			continue
+		}
+		if int(code) < len(d.decLen) {
+			binary.LittleEndian.PutUint64(tmp[:], d.decSymbol[code])
+			buf = append(buf, tmp[:d.decLen[code]]...)
+		}
+	}
+	return buf
+}
+
+// DecodeAll decompresses src into a newly allocated slice.
+func (d *DecoderOnly) DecodeAll(src []byte) []byte {
+	return d.Decode(nil, src)
+}
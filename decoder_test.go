@@ -0,0 +1,55 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecoderOnlyMatchesTable(t *testing.T) {
+	input := []byte(strings.Repeat("decode-only services cache many tables ", 30))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+
+	dec := tbl.Decoder()
+	want := tbl.Decode(nil, compressed)
+	if got := dec.Decode(nil, compressed); !bytes.Equal(got, want) {
+		t.Fatalf("DecoderOnly diverges from Table.Decode")
+	}
+	if !bytes.Equal(want, input) {
+		t.Fatalf("roundtrip mismatch")
+	}
+}
+
+func TestDecoderOnlyEscapes(t *testing.T) {
+	tbl := Train([][]byte{bytes.Repeat([]byte("unrelated"), 50)})
+	input := []byte("\x00\x01XYZ escape heavy \xff\xfe")
+	compressed := tbl.Encode(nil, input)
+
+	dec := tbl.Decoder()
+	if got := dec.DecodeAll(compressed); !bytes.Equal(got, input) {
+		t.Fatalf("escape-heavy decode mismatch: %q", got)
+	}
+}
+
+func TestDecoderOnlyExtended(t *testing.T) {
+	tbl := Train(diverseCorpus(), WithExtendedCodes(true))
+	input := diverseCorpus()[0]
+	compressed := tbl.Encode(nil, input)
+
+	dec := tbl.Decoder()
+	if got := dec.DecodeAll(compressed); !bytes.Equal(got, input) {
+		t.Fatalf("extended decode mismatch")
+	}
+}
+
+func TestDecoderOnlyMacros(t *testing.T) {
+	inputs := macroCorpus()
+	tbl := Train(inputs, WithMacros(true))
+	compressed := tbl.Encode(nil, inputs[0])
+
+	dec := tbl.Decoder()
+	if got := dec.DecodeAll(compressed); !bytes.Equal(got, inputs[0]) {
+		t.Fatalf("macro decode mismatch")
+	}
+}
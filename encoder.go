@@ -0,0 +1,91 @@
+package fsst
+
+// CompactEncoder is a compact encode-side view of a Table, symmetric to
+// DecoderOnly. A full Table spends ~130KB on encoder lookup structures
+// (shortCodes alone is 128KB); CompactEncoder keeps only the finalized symbol
+// list and metadata — a few KB — and rebuilds the lookup structures on
+// demand inside Encode. That trades per-call setup time for far smaller
+// resident size, which wins when caching many tables that each encode
+// rarely. For hot encode paths keep the full Table instead.
+type CompactEncoder struct {
+	symbols       []symbol
+	nSymbols      uint16
+	suffixLim     uint16
+	lenHisto      [8]uint16
+	extended      bool
+	macroBase     uint16
+	macros        [][2]uint16
+	backrefWindow uint8
+	ordEscMin     uint8 // order-preserving escape bound; 0 = not ordered
+	ordLeaves     [][]byte
+	freqRemap     []uint8 // frequency wire-code remap; nil = identity
+}
+
+// Encoder returns a compact encode-only view of the table. The returned
+// value shares no state with t and remains valid if t is discarded.
+func (t *Table) Encoder() *CompactEncoder {
+	e := &CompactEncoder{
+		symbols:   append([]symbol(nil), t.symbols[:t.nSymbols]...),
+		nSymbols:  t.nSymbols,
+		suffixLim: t.suffixLim,
+		lenHisto:  t.lenHisto,
+		extended:  t.extended,
+	}
+	if t.hasMacros {
+		e.macroBase = t.macroBase
+		e.macros = append([][2]uint16(nil), t.macros...)
+	}
+	e.backrefWindow = t.backrefWindow
+	e.freqRemap = append([]uint8(nil), t.freqRemap...)
+	if t.ordered {
+		e.ordEscMin = t.ordEscMin
+		e.ordLeaves = make([][]byte, len(t.ordLeaves))
+		for i, leaf := range t.ordLeaves {
+			e.ordLeaves[i] = append([]byte(nil), leaf...)
+		}
+	}
+	return e
+}
+
+// Encode compresses input with the same semantics and output as Table.Encode.
+// Each call materializes a transient Table (rebuilding shortCodes and the
+// hash tables) that is discarded afterwards, so the resident footprint stays
+// at the CompactEncoder's few KB.
+func (e *CompactEncoder) Encode(buf, input []byte) []byte {
+	return e.materialize().Encode(buf, input)
+}
+
+// materialize reconstructs a full Table from the compact state. The lookup
+// structures are rebuilt lazily by the first Encode on it.
+func (e *CompactEncoder) materialize() *Table {
+	t := newTable()
+	copy(t.symbols[:e.nSymbols], e.symbols)
+	t.nSymbols = e.nSymbols
+	t.suffixLim = e.suffixLim
+	t.lenHisto = e.lenHisto
+	t.extended = e.extended
+	t.uniformLen = computeUniformLen(e.lenHisto)
+	if e.macros != nil {
+		t.hasMacros = true
+		t.macroBase = e.macroBase
+		t.macros = e.macros
+		t.macroLookup = make(map[[2]uint16]uint16, len(e.macros))
+		for i, pair := range e.macros {
+			t.macroLookup[pair] = e.macroBase + uint16(i)
+		}
+	}
+	t.backrefWindow = e.backrefWindow
+	if e.freqRemap != nil {
+		t.freqRemap = e.freqRemap
+		t.freqInv = make([]uint8, len(e.freqRemap))
+		for code, wire := range e.freqRemap {
+			t.freqInv[wire] = uint8(code)
+		}
+	}
+	if e.ordEscMin > 0 {
+		// validated when the source table was built, so this cannot fail
+		_ = t.buildOrdered(e.ordLeaves, int(e.ordEscMin))
+	}
+	t.accelReady = false
+	return t
+}
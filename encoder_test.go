@@ -0,0 +1,46 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompactEncoderMatchesTable(t *testing.T) {
+	input := []byte(strings.Repeat("encode-heavy workloads cache many tables ", 30))
+	tbl := Train([][]byte{input})
+	want := tbl.Encode(nil, input)
+
+	enc := tbl.Encoder()
+	if got := enc.Encode(nil, input); !bytes.Equal(got, want) {
+		t.Fatalf("CompactEncoder output differs from Table.Encode")
+	}
+	// And the output still decodes with the original table.
+	if got := tbl.Decode(nil, enc.Encode(nil, input)); !bytes.Equal(got, input) {
+		t.Fatalf("CompactEncoder output fails to decode")
+	}
+}
+
+func TestCompactEncoderSurvivesTableDiscard(t *testing.T) {
+	input := []byte(strings.Repeat("independent lifetime ", 20))
+	tbl := Train([][]byte{input})
+	want := tbl.Encode(nil, input)
+	enc := tbl.Encoder()
+
+	// Clobber the source table; the compact encoder must be unaffected.
+	*tbl = *newTable()
+	if got := enc.Encode(nil, input); !bytes.Equal(got, want) {
+		t.Fatalf("CompactEncoder depends on the discarded Table")
+	}
+}
+
+func TestCompactEncoderMacros(t *testing.T) {
+	inputs := macroCorpus()
+	tbl := Train(inputs, WithMacros(true))
+	want := tbl.Encode(nil, inputs[0])
+
+	enc := tbl.Encoder()
+	if got := enc.Encode(nil, inputs[0]); !bytes.Equal(got, want) {
+		t.Fatalf("macro-enabled CompactEncoder output differs")
+	}
+}
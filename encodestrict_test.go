@@ -0,0 +1,44 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncodeStrictCoveredInput(t *testing.T) {
+	// A table holding a 1-byte symbol for every byte of the alphabet can
+	// never escape — the guarantee strict mode is meant to police.
+	alphabet := "abcdefghijklmnopqrstuvwxyz "
+	p := &TableProto{Version: fsstVersion}
+	for i := 0; i < len(alphabet); i++ {
+		p.Symbols = append(p.Symbols, []byte{alphabet[i]})
+	}
+	tbl, err := FromProto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := []byte(strings.Repeat("fully covered corpus ", 100))
+	comp, err := tbl.EncodeStrict(input)
+	if err != nil {
+		t.Fatalf("EncodeStrict on covered input: %v", err)
+	}
+	if want := tbl.Encode(nil, input); !bytes.Equal(comp, want) {
+		t.Fatalf("EncodeStrict output differs from Encode")
+	}
+}
+
+func TestEncodeStrictUncoveredByte(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("ascii only corpus ", 100))})
+
+	// 0xFF never appears in the corpus, so it must escape — and strict mode
+	// must turn that into an error naming the byte.
+	input := []byte("ascii only corpus \xff trailing")
+	if _, err := tbl.EncodeStrict(input); !errors.Is(err, ErrEscapeNeeded) {
+		t.Fatalf("uncovered byte not reported: err=%v", err)
+	} else if !strings.Contains(err.Error(), "0xff") {
+		t.Fatalf("error does not name the offending byte: %v", err)
+	}
+}
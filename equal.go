@@ -0,0 +1,55 @@
+package fsst
+
+// Equal reports whether the two tables hold the same learned symbols with the
+// same code assignments — everything that determines encode and decode output.
+// Lazy caches, scratch buffers, and strategy flags are ignored, so a table
+// compares equal to its Clone and to a freshly deserialized copy of itself.
+// It allocates nothing, unlike comparing WriteTo output, making it suitable
+// for deduplicating identical tables in a content-keyed cache.
+func (t *Table) Equal(other *Table) bool {
+	if t == other {
+		return true
+	}
+	if other == nil {
+		return false
+	}
+	if t.nSymbols != other.nSymbols ||
+		t.suffixLim != other.suffixLim ||
+		t.lenHisto != other.lenHisto ||
+		t.extended != other.extended ||
+		t.hasMacros != other.hasMacros ||
+		t.backrefWindow != other.backrefWindow ||
+		t.ordered != other.ordered ||
+		t.ordEscMin != other.ordEscMin {
+		return false
+	}
+	for i := range int(t.nSymbols) {
+		a, b := t.symbols[i], other.symbols[i]
+		if a.length() != b.length() {
+			return false
+		}
+		mask := ^uint64(0) >> (64 - 8*a.length())
+		if a.val&mask != b.val&mask {
+			return false
+		}
+	}
+	if len(t.freqRemap) != len(other.freqRemap) {
+		return false
+	}
+	for i := range t.freqRemap {
+		if t.freqRemap[i] != other.freqRemap[i] {
+			return false
+		}
+	}
+	if t.hasMacros {
+		if t.macroBase != other.macroBase || len(t.macros) != len(other.macros) {
+			return false
+		}
+		for i := range t.macros {
+			if t.macros[i] != other.macros[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTableEqual(t *testing.T) {
+	corpus := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	tbl := Train([][]byte{corpus})
+
+	if !tbl.Equal(tbl) {
+		t.Fatalf("table not equal to itself")
+	}
+	if !tbl.Equal(tbl.Clone()) {
+		t.Fatalf("table not equal to its clone")
+	}
+	if tbl.Equal(nil) {
+		t.Fatalf("table equal to nil")
+	}
+
+	// A deserialized copy compares equal even though its lazy caches differ.
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var loaded Table
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	tbl.Prepare() // caches on one side only
+	if !tbl.Equal(&loaded) {
+		t.Fatalf("table not equal to its deserialized copy")
+	}
+
+	other := Train([][]byte{[]byte(strings.Repeat("completely different data ", 100))})
+	if tbl.Equal(other) {
+		t.Fatalf("tables from different corpora compare equal")
+	}
+}
+
+func TestTableEqualCodeOrderMatters(t *testing.T) {
+	// Same symbols under different code assignments encode differently, so
+	// they must not compare equal.
+	a, err := FromProto(&TableProto{Version: fsstVersion, Symbols: [][]byte{[]byte("ab"), []byte("cd")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := FromProto(&TableProto{Version: fsstVersion, Symbols: [][]byte{[]byte("cd"), []byte("ab")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Equal(b) {
+		t.Fatalf("tables with swapped code assignments compare equal")
+	}
+}
@@ -0,0 +1,42 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// The escape code must never be occupied by a learned symbol: training caps
+// codes at 254, finalize panics on a miscount, and ReadFrom rejects headers
+// whose length histogram would schedule a symbol at code 255.
+func TestEscapeCodeIsReserved(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("escape code stays sacrosanct ", 50))})
+	if tbl.nSymbols > fsstMaxSymbols {
+		t.Fatalf("training produced %d symbols, exceeding %d", tbl.nSymbols, fsstMaxSymbols)
+	}
+
+	// A literal escape sequence must always decode as a literal byte.
+	comp := []byte{fsstEscapeCode, 'x'}
+	if got := tbl.Decode(nil, comp); !bytes.Equal(got, []byte("x")) {
+		t.Fatalf("escape sequence decoded to %q, want %q", got, "x")
+	}
+}
+
+func TestReadFromRejectsHistoMismatch(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("histogram validation ", 50))})
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Inflate the 1-byte-symbol count so the histogram claims one more symbol
+	// than the header's nSymbols — the schedule would spill past code 254.
+	blob := buf.Bytes()
+	blob[8]++ // lenHisto[0] lives right after the 8-byte version word
+
+	var tampered Table
+	if _, err := tampered.ReadFrom(bytes.NewReader(blob)); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("tampered histogram accepted: err=%v, want ErrBadFormat", err)
+	}
+}
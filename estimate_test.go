@@ -0,0 +1,43 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodedLenMatchesEncode(t *testing.T) {
+	inputs := [][]byte{
+		[]byte(""),
+		[]byte("x"),
+		[]byte("hello world, hello there, hello again"),
+		bytes.Repeat([]byte("the quick brown fox "), 100),
+		[]byte(strings.Repeat("abcdefghijklmnopqrstuvwxyz", 50)),
+	}
+	tbl := Train(inputs)
+	for i := range inputs {
+		want := len(tbl.EncodeAll(inputs[i]))
+		got := tbl.EncodedLen(inputs[i])
+		if got != want {
+			t.Fatalf("EncodedLen mismatch for input %d: got %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestEstimateRatio(t *testing.T) {
+	repetitive := bytes.Repeat([]byte("hello world "), 200)
+	tbl := Train([][]byte{repetitive})
+
+	ratio := tbl.EstimateRatio([][]byte{repetitive})
+	actual := float64(len(tbl.EncodeAll(repetitive))) / float64(len(repetitive))
+	if ratio != actual {
+		t.Fatalf("EstimateRatio %f does not match actual ratio %f", ratio, actual)
+	}
+	if ratio >= 1 {
+		t.Fatalf("expected compression on repetitive data, got ratio %f", ratio)
+	}
+
+	if got := tbl.EstimateRatio(nil); got != 0 {
+		t.Fatalf("EstimateRatio on empty inputs: got %f, want 0", got)
+	}
+}
@@ -1,6 +1,7 @@
 package fsst
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -19,3 +20,28 @@ func Example() {
 	// hello world
 	// hello there
 }
+
+func ExampleCodec() {
+	type event struct {
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+	}
+	sample := event{Level: "info", Msg: "request served"}
+	raw, _ := json.Marshal(sample)
+	tbl := Train([][]byte{raw})
+
+	codec := Codec[event]{
+		Table:   tbl,
+		Marshal: func(e event) []byte { b, _ := json.Marshal(e); return b },
+		Unmarshal: func(b []byte) event {
+			var e event
+			json.Unmarshal(b, &e)
+			return e
+		},
+	}
+
+	comp := codec.Encode(nil, event{Level: "warn", Msg: "request served"})
+	fmt.Println(codec.Decode(comp).Msg)
+	// Output:
+	// request served
+}
@@ -0,0 +1,73 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// diverseCorpus builds inputs with many distinct repeated tokens so training
+// can profit from more than 255 symbols.
+func diverseCorpus() [][]byte {
+	var inputs [][]byte
+	for i := range 400 {
+		token := fmt.Sprintf("<tok%03d>", i)
+		inputs = append(inputs, bytes.Repeat([]byte(token), 8))
+	}
+	return inputs
+}
+
+func TestExtendedCodesRoundtrip(t *testing.T) {
+	inputs := diverseCorpus()
+	tbl := Train(inputs, WithExtendedCodes(true))
+	if !tbl.extended {
+		t.Fatalf("table not marked extended")
+	}
+	for i := range inputs {
+		comp := tbl.EncodeAll(inputs[i])
+		got := tbl.DecodeAll(comp)
+		if !bytes.Equal(got, inputs[i]) {
+			t.Fatalf("extended roundtrip mismatch for input %d", i)
+		}
+	}
+}
+
+func TestExtendedCodesSymbolCount(t *testing.T) {
+	inputs := diverseCorpus()
+	normal := Train(inputs)
+	extended := Train(inputs, WithExtendedCodes(true))
+	if normal.nSymbols > fsstMaxSymbols {
+		t.Fatalf("default mode exceeded 255 symbols: %d", normal.nSymbols)
+	}
+	if extended.nSymbols <= normal.nSymbols {
+		t.Fatalf("extended mode learned no extra symbols: %d <= %d", extended.nSymbols, normal.nSymbols)
+	}
+}
+
+func TestExtendedCodesSerialization(t *testing.T) {
+	inputs := diverseCorpus()
+	tbl := Train(inputs, WithExtendedCodes(true))
+
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFrom(&buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !tbl2.extended {
+		t.Fatalf("extended flag lost in serialization")
+	}
+	for i := range inputs {
+		comp1 := tbl.EncodeAll(inputs[i])
+		comp2 := tbl2.EncodeAll(inputs[i])
+		if !bytes.Equal(comp1, comp2) {
+			t.Fatalf("serialized extended table changed encoding for input %d", i)
+		}
+		got := tbl2.DecodeAll(comp2)
+		if !bytes.Equal(got, inputs[i]) {
+			t.Fatalf("serialized extended roundtrip mismatch for input %d", i)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package fsst
+
+// Fingerprint returns a stable 64-bit content hash of the table for keying
+// caches and detecting identical tables. It covers everything Equal compares
+// — symbols in canonical code order, suffixLim, macro pairs, and the backref
+// window — and nothing
+// lazy, so the value survives serialization round trips and Clone. The hash
+// chains fsstHash over the symbol values, making it cheap (a few hundred
+// multiplies) and independent of architecture and Go version. It is a cache
+// key, not a cryptographic digest: colliding tables are unlikely but
+// possible, so pair it with Equal when correctness matters.
+func (t *Table) Fingerprint() uint64 {
+	h := fsstHash(uint64(t.nSymbols)<<16 | uint64(t.suffixLim))
+	if t.extended {
+		h = fsstHash(h ^ 1)
+	}
+	for i := range int(t.nSymbols) {
+		sym := t.symbols[i]
+		mask := ^uint64(0) >> (64 - 8*sym.length())
+		h = fsstHash(h ^ sym.val&mask)
+		h = fsstHash(h ^ uint64(sym.length()))
+	}
+	if t.hasMacros {
+		h = fsstHash(h ^ uint64(t.macroBase))
+		for _, pair := range t.macros {
+			h = fsstHash(h ^ (uint64(pair[0])<<16 | uint64(pair[1])))
+		}
+	}
+	if t.backrefWindow > 0 {
+		h = fsstHash(h ^ uint64(t.backrefWindow)<<32)
+	}
+	if t.ordered {
+		h = fsstHash(h ^ uint64(t.ordEscMin)<<40)
+	}
+	for _, wire := range t.freqRemap {
+		h = fsstHash(h ^ (uint64(wire) | 1<<48))
+	}
+	return h
+}
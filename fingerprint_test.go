@@ -0,0 +1,41 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	corpus := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	tbl := Train([][]byte{corpus})
+	fp := tbl.Fingerprint()
+
+	// Identical training gives an identical fingerprint.
+	if got := Train([][]byte{corpus}).Fingerprint(); got != fp {
+		t.Fatalf("retrained fingerprint %x, want %x", got, fp)
+	}
+
+	// Stable across serialization and cloning; lazy caches don't matter.
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var loaded Table
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Fingerprint(); got != fp {
+		t.Fatalf("fingerprint after reload %x, want %x", got, fp)
+	}
+	tbl.Prepare()
+	if got := tbl.Fingerprint(); got != fp {
+		t.Fatalf("fingerprint changed after Prepare: %x, want %x", got, fp)
+	}
+
+	// Different content, different fingerprint.
+	other := Train([][]byte{[]byte(strings.Repeat("completely different data ", 100))})
+	if other.Fingerprint() == fp {
+		t.Fatalf("distinct tables share a fingerprint")
+	}
+}
@@ -0,0 +1,55 @@
+package fsst
+
+import (
+	"encoding/binary"
+)
+
+// EncodeFramed compresses input like Encode but prepends a uvarint length of
+// the compressed payload, making records self-delimiting when concatenated.
+// buf can be nil or undersized; it will be grown as needed.
+func (t *Table) EncodeFramed(buf, input []byte) []byte {
+	t.frameBuf = t.Encode(t.frameBuf, input)
+	var hdr [binary.MaxVarintLen64]byte
+	hdrLen := binary.PutUvarint(hdr[:], uint64(len(t.frameBuf)))
+	need := hdrLen + len(t.frameBuf)
+	if cap(buf) < need {
+		buf = make([]byte, need)
+	}
+	buf = buf[:need]
+	copy(buf, hdr[:hdrLen])
+	copy(buf[hdrLen:], t.frameBuf)
+	return buf
+}
+
+// DecodeFramed reads one framed record from the start of src and returns the
+// decoded payload plus the number of source bytes consumed (header and
+// payload). It returns (nil, 0) if src does not start with a complete frame.
+// buf follows the same reuse semantics as Decode.
+func (t *Table) DecodeFramed(buf, src []byte) (decoded []byte, consumed int) {
+	payloadLen, hdrLen := binary.Uvarint(src)
+	// Compare against the remaining length without adding to payloadLen: a
+	// crafted uvarint near 2^64 would wrap the sum and slip past the check.
+	if hdrLen <= 0 || payloadLen > uint64(len(src)-hdrLen) {
+		return nil, 0
+	}
+	end := hdrLen + int(payloadLen)
+	return t.Decode(buf, src[hdrLen:end]), end
+}
+
+// DecodeN decodes up to n framed records from the start of src and reports
+// how many source bytes they spanned, so a reader can page through a large
+// concatenation of EncodeFramed records: decode a page, advance src by
+// consumed, repeat. It stops cleanly at the first incomplete frame (fewer
+// than n records are returned and the partial frame is not consumed); n <= 0
+// returns (nil, 0). Each record is freshly allocated.
+func (t *Table) DecodeN(src []byte, n int) (records [][]byte, consumed int) {
+	for len(records) < n {
+		decoded, used := t.DecodeFramed(nil, src[consumed:])
+		if used == 0 {
+			break
+		}
+		records = append(records, decoded)
+		consumed += used
+	}
+	return records, consumed
+}
@@ -0,0 +1,112 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestFramedRoundtrip(t *testing.T) {
+	records := [][]byte{
+		[]byte("first record"),
+		[]byte(""),
+		bytes.Repeat([]byte{0x01, 0x23, 0x45, 0x67}, 100), // escapes heavily, >127 compressed bytes
+		[]byte("last record with some shared words: record record"),
+	}
+	tbl := Train(records)
+
+	// Concatenate framed records into one stream
+	var stream []byte
+	for i := range records {
+		stream = append(stream, tbl.EncodeFramed(nil, records[i])...)
+	}
+
+	// Stream-decode them back
+	pos := 0
+	for i := range records {
+		decoded, consumed := tbl.DecodeFramed(nil, stream[pos:])
+		if consumed == 0 {
+			t.Fatalf("record %d: no frame consumed", i)
+		}
+		if !bytes.Equal(decoded, records[i]) {
+			t.Fatalf("record %d: got %q, want %q", i, decoded, records[i])
+		}
+		pos += consumed
+	}
+	if pos != len(stream) {
+		t.Fatalf("stream not fully consumed: %d != %d", pos, len(stream))
+	}
+}
+
+func TestDecodeFramedTruncated(t *testing.T) {
+	tbl := Train([][]byte{[]byte("hello world")})
+	framed := tbl.EncodeFramed(nil, []byte("hello world"))
+
+	if _, consumed := tbl.DecodeFramed(nil, framed[:len(framed)-1]); consumed != 0 {
+		t.Fatalf("truncated frame reported consumed=%d", consumed)
+	}
+	if _, consumed := tbl.DecodeFramed(nil, nil); consumed != 0 {
+		t.Fatalf("empty source reported consumed=%d", consumed)
+	}
+
+	// A header whose uvarint length is near 2^64 must be rejected, not wrap
+	// the bounds arithmetic and panic.
+	hostile := append(bytes.Repeat([]byte{0xFF}, 9), 0x01, 'x', 'y', 'z')
+	if _, consumed := tbl.DecodeFramed(nil, hostile); consumed != 0 {
+		t.Fatalf("hostile length reported consumed=%d", consumed)
+	}
+}
+
+func TestDecodeN(t *testing.T) {
+	var records [][]byte
+	for i := 0; i < 10; i++ {
+		records = append(records, []byte(fmt.Sprintf("paged record number %d", i)))
+	}
+	tbl := Train(records)
+
+	var stream []byte
+	for i := range records {
+		stream = append(stream, tbl.EncodeFramed(nil, records[i])...)
+	}
+
+	// Page through three at a time.
+	pos, got := 0, 0
+	for pos < len(stream) {
+		page, consumed := tbl.DecodeN(stream[pos:], 3)
+		if consumed == 0 {
+			t.Fatalf("no progress at offset %d", pos)
+		}
+		for _, rec := range page {
+			if !bytes.Equal(rec, records[got]) {
+				t.Fatalf("record %d: got %q, want %q", got, rec, records[got])
+			}
+			got++
+		}
+		pos += consumed
+	}
+	if got != len(records) || pos != len(stream) {
+		t.Fatalf("paged %d records over %d bytes, want %d over %d", got, pos, len(records), len(stream))
+	}
+
+	// Asking past the end stops cleanly at the last complete frame.
+	page, consumed := tbl.DecodeN(stream[:len(stream)-1], len(records))
+	if len(page) != len(records)-1 {
+		t.Fatalf("truncated stream yielded %d records, want %d", len(page), len(records)-1)
+	}
+	if consumed >= len(stream) {
+		t.Fatalf("consumed %d bytes including a partial frame", consumed)
+	}
+	if page, consumed := tbl.DecodeN(stream, 0); page != nil || consumed != 0 {
+		t.Fatalf("DecodeN(_, 0) = %d records, %d consumed; want none", len(page), consumed)
+	}
+
+	// A crafted header mid-stream (uvarint length near 2^64) must stop the
+	// page at the records before it, not panic the whole read.
+	hostile := append(append([]byte(nil), stream...), bytes.Repeat([]byte{0xFF}, 9)...)
+	hostile = append(hostile, 0x01, 'x', 'y', 'z')
+	page, consumed = tbl.DecodeN(hostile, len(records)+1)
+	if len(page) != len(records) || consumed != len(stream) {
+		t.Fatalf("hostile tail: paged %d records over %d bytes, want %d over %d",
+			len(page), consumed, len(records), len(stream))
+	}
+}
@@ -0,0 +1,109 @@
+package fsst
+
+import (
+	"errors"
+	"io"
+	"sort"
+)
+
+// ErrCodesPinned is returned by AssignCodesByFrequency for table modes whose
+// code layout carries meaning of its own (extended, macros, backrefs,
+// ordered) and therefore cannot be permuted.
+var ErrCodesPinned = errors.New("fsst: table mode does not allow code reassignment")
+
+// AssignCodesByFrequency reorders the wire codes so the most-used symbol is
+// emitted as code 0, the next as code 1, and so on, measured by encoding the
+// provided inputs. Code values are all one byte, so this changes nothing for
+// plain storage — it prepares the code stream for a downstream stage where
+// small values are cheaper, like varint packing or entropy coding.
+//
+// The canonical code layout (finalize's length grouping, which the chunk
+// encoders and the serialized symbol block rely on) is kept internally; the
+// permutation is applied to the encoded stream as a post-pass and to the
+// decoder arrays, the same way macro rewriting works. Encode/decode
+// roundtrips, introspection via SymbolOf, and serialization (a remap trailer
+// under its own format flag) all stay consistent. Calling it again with
+// different inputs replaces the assignment; already-encoded streams must be
+// decoded with the table state that produced them.
+func (t *Table) AssignCodesByFrequency(inputs [][]byte) error {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 || t.ordered {
+		return ErrCodesPinned
+	}
+	if t.nSymbols == 0 {
+		return nil
+	}
+
+	// Count canonical code usage: drop any previous remap so the tally is in
+	// canonical terms, then encode the inputs and walk the code stream.
+	t.freqRemap, t.freqInv = nil, nil
+	counts := make([]int, t.nSymbols)
+	var comp []byte
+	for _, input := range inputs {
+		comp = t.Encode(comp, input)
+		for i := 0; i < len(comp); i++ {
+			b := comp[i]
+			if b == fsstEscapeCode {
+				i++
+				continue
+			}
+			counts[b]++
+		}
+	}
+
+	// Most-used first, canonical code as deterministic tiebreak.
+	order := make([]int, t.nSymbols)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if counts[order[i]] != counts[order[j]] {
+			return counts[order[i]] > counts[order[j]]
+		}
+		return order[i] < order[j]
+	})
+
+	remap := make([]uint8, t.nSymbols)
+	inv := make([]uint8, t.nSymbols)
+	for rank, code := range order {
+		remap[code] = uint8(rank)
+		inv[rank] = uint8(code)
+	}
+	t.freqRemap, t.freqInv = remap, inv
+	t.decReady = false
+	return nil
+}
+
+// remapWireCodes rewrites an encoded stream in place from canonical to wire
+// codes, skipping the literal byte after each escape.
+func (t *Table) remapWireCodes(buf []byte) {
+	for i := 0; i < len(buf); i++ {
+		b := buf[i]
+		if b == fsstEscapeCode {
+			i++
+			continue
+		}
+		buf[i] = t.freqRemap[b]
+	}
+}
+
+// readFreqTrailer reads the code remap that follows the plain symbol block in
+// fsstFormatFreqCodes and validates that it is a permutation of the code
+// space — anything else would alias two symbols onto one wire code.
+func (t *Table) readFreqTrailer(r io.Reader, n int64) (int64, error) {
+	remap := make([]uint8, t.nSymbols)
+	if _, err := io.ReadFull(r, remap); err != nil {
+		return n, err
+	}
+	n += int64(len(remap))
+	inv := make([]uint8, t.nSymbols)
+	seen := make([]bool, t.nSymbols)
+	for code, wire := range remap {
+		if int(wire) >= int(t.nSymbols) || seen[wire] {
+			return n, ErrBadFormat
+		}
+		seen[wire] = true
+		inv[wire] = uint8(code)
+	}
+	t.freqRemap, t.freqInv = remap, inv
+	return n, nil
+}
@@ -0,0 +1,99 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAssignCodesByFrequency(t *testing.T) {
+	inputs := [][]byte{[]byte(strings.Repeat("GET /api/users HTTP/1.1 host=example.com ", 150))}
+	tbl := Train(inputs)
+	if err := tbl.AssignCodesByFrequency(inputs); err != nil {
+		t.Fatalf("AssignCodesByFrequency: %v", err)
+	}
+
+	// Roundtrip must survive the permutation, including escaped bytes.
+	for _, input := range [][]byte{inputs[0], []byte("unseen \x00\xff bytes")} {
+		comp := tbl.Encode(nil, input)
+		if got := tbl.Decode(nil, comp); !bytes.Equal(got, input) {
+			t.Fatalf("roundtrip mismatch: got %q, want %q", got, input)
+		}
+		if tbl.EncodedLen(input) != len(comp) {
+			t.Fatal("EncodedLen changed under code reassignment")
+		}
+	}
+
+	// Wire code usage must be non-increasing: the most-used symbol went out
+	// as code 0.
+	counts := make([]int, tbl.NumSymbols())
+	comp := tbl.Encode(nil, inputs[0])
+	for i := 0; i < len(comp); i++ {
+		if comp[i] == fsstEscapeCode {
+			i++
+			continue
+		}
+		counts[comp[i]]++
+	}
+	for i := 1; i < len(counts); i++ {
+		if counts[i] > counts[i-1] {
+			t.Fatalf("wire code %d used %d times, more than code %d (%d)", i, counts[i], i-1, counts[i-1])
+		}
+	}
+
+	// SymbolOf speaks wire codes: each code must decode to its symbol.
+	for code := 0; code < tbl.NumSymbols(); code++ {
+		if got := tbl.Decode(nil, []byte{byte(code)}); !bytes.Equal(got, tbl.SymbolOf(code)) {
+			t.Fatalf("code %d: Decode = %q, SymbolOf = %q", code, got, tbl.SymbolOf(code))
+		}
+	}
+}
+
+func TestAssignCodesByFrequencySerialization(t *testing.T) {
+	inputs := [][]byte{[]byte(strings.Repeat("status=ok level=info module=auth ", 150))}
+	tbl := Train(inputs)
+	if err := tbl.AssignCodesByFrequency(inputs); err != nil {
+		t.Fatalf("AssignCodesByFrequency: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := tbl.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if int(n) != tbl.SerializedLen() {
+		t.Fatalf("wrote %d bytes, SerializedLen says %d", n, tbl.SerializedLen())
+	}
+	var loaded Table
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if !loaded.Equal(tbl) || loaded.Fingerprint() != tbl.Fingerprint() {
+		t.Fatal("reloaded table differs from original")
+	}
+	input := []byte("status=ok level=info")
+	if !bytes.Equal(loaded.Encode(nil, input), tbl.Encode(nil, input)) {
+		t.Fatal("reloaded table encodes differently")
+	}
+
+	// A trailer that is not a permutation must be rejected.
+	raw := make([]byte, 0, tbl.SerializedLen())
+	raw, _ = tbl.AppendBinary(raw)
+	raw[len(raw)-1] = raw[len(raw)-2] // duplicate a wire code
+	if _, err := loaded.ReadFrom(bytes.NewReader(raw)); err != ErrBadFormat {
+		t.Fatalf("corrupt remap trailer: err = %v, want ErrBadFormat", err)
+	}
+}
+
+func TestAssignCodesByFrequencyPinnedModes(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("pinned mode corpus words ", 100))}
+	for _, tbl := range []*Table{
+		Train(corpus, WithExtendedCodes(true)),
+		Train(corpus, WithBackrefs(64)),
+		TrainOrderPreserving(corpus),
+	} {
+		if err := tbl.AssignCodesByFrequency(corpus); err != ErrCodesPinned {
+			t.Fatalf("err = %v, want ErrCodesPinned", err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package fsst
+
+// FrozenTable is an immutable view of a trained Table for read-path sharing.
+// FreezeTable builds every lazy encoder and decoder structure up front, and
+// the FrozenTable methods never touch shared scratch state afterwards, so one
+// instance can serve any number of goroutines without synchronization — the
+// footgun-free alternative to warming a Table by hand. A frozen table cannot
+// be retrained or refreshed; train a new Table instead.
+type FrozenTable struct {
+	t *Table
+}
+
+// FreezeTable prepares t eagerly and returns an immutable handle over it.
+// The caller must stop using t directly afterwards: mutating it (Refresh,
+// manual symbol edits) would pull the rug out from under the frozen view.
+func FreezeTable(t *Table) *FrozenTable {
+	t.Prepare()
+	return &FrozenTable{t: t}
+}
+
+// Encode compresses input exactly like Table.Encode but without the shared
+// tail scratch buffer, so concurrent calls never race. Throughput matches a
+// warmed-up Table; only the sub-8-byte tail pays for a small stack copy.
+func (f *FrozenTable) Encode(buf, input []byte) []byte {
+	t := f.t
+	if len(input) > MaxEncodeLen {
+		panic("fsst: input exceeds MaxEncodeLen; use EncodeStream for larger data")
+	}
+
+	if cap(buf) < 2*len(input)+fsstOutputPadding {
+		buf = make([]byte, 2*len(input)+fsstOutputPadding)
+	} else {
+		buf = buf[:cap(buf)]
+	}
+	if t.ordered {
+		// The trie encoder reads only immutable state, so it is safe to
+		// share across goroutines as-is.
+		return buf[:t.encodeOrderedAt(buf, 0, input)]
+	}
+
+	outPos := 0
+	inputLen := len(input)
+	chunkLen := t.chunkLen()
+	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+
+	position := 0
+	for position+8 <= inputLen {
+		end := min(position+chunkLen, inputLen-7) - position
+		var consumed int
+		outPos, consumed = t.encodeChunk(buf, outPos, input[position:], end, end+7, byteLim)
+		position += consumed
+	}
+	if position < inputLen {
+		// Zero-valued per call: at most 7 tail bytes plus padding for the
+		// unaligned loads, and no shared buffer to race on.
+		var tailBuf [fsstChunkPadding + 7]byte
+		tailLen := inputLen - position
+		copy(tailBuf[:tailLen], input[position:])
+		outPos, _ = t.encodeChunk(buf, outPos, tailBuf[:], tailLen, tailLen, byteLim)
+	}
+	if t.hasMacros {
+		return t.rewriteMacros(buf[:outPos])
+	}
+	if t.freqRemap != nil {
+		t.remapWireCodes(buf[:outPos])
+	}
+	return buf[:outPos]
+}
+
+// Decode decompresses src. The decoder arrays were built by FreezeTable, so
+// this is a pure table lookup with no lazy initialization to race on. buf
+// follows the same reuse semantics as Table.Decode.
+func (f *FrozenTable) Decode(buf, src []byte) []byte {
+	return f.t.Decode(buf, src)
+}
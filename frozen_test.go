@@ -0,0 +1,76 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestFrozenTable(t *testing.T) {
+	data, err := os.ReadFile("testdata/en_mobydick.txt")
+	if err != nil {
+		t.Skip("corpus not available")
+	}
+	data = data[:1<<15]
+	tbl := Train([][]byte{data})
+	want := tbl.Encode(nil, data)
+
+	frozen := FreezeTable(tbl)
+	if got := frozen.Encode(nil, data); !bytes.Equal(got, want) {
+		t.Fatalf("frozen encode differs from Table encode")
+	}
+	if got := frozen.Decode(nil, want); !bytes.Equal(got, data) {
+		t.Fatalf("frozen decode roundtrip failed")
+	}
+
+	// Concurrent encodes and decodes share one frozen handle with no
+	// synchronization; every goroutine must still get identical bytes.
+	var wg sync.WaitGroup
+	errs := make(chan string, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if got := frozen.Encode(nil, data); !bytes.Equal(got, want) {
+					errs <- "concurrent encode mismatch"
+					return
+				}
+				if got := frozen.Decode(nil, want); !bytes.Equal(got, data) {
+					errs <- "concurrent decode mismatch"
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	if msg, ok := <-errs; ok {
+		t.Fatal(msg)
+	}
+}
+
+func BenchmarkFrozenEncode(b *testing.B) {
+	data, err := os.ReadFile("testdata/en_mobydick.txt")
+	if err != nil {
+		b.Skip("corpus not available")
+	}
+	data = data[:1<<16]
+	tbl := Train([][]byte{data})
+	frozen := FreezeTable(tbl)
+	buf := make([]byte, 2*len(data)+fsstOutputPadding)
+
+	b.Run("table", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			tbl.Encode(buf, data)
+		}
+	})
+	b.Run("frozen", func(b *testing.B) {
+		b.SetBytes(int64(len(data)))
+		for i := 0; i < b.N; i++ {
+			frozen.Encode(buf, data)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFullCoverageSample(t *testing.T) {
+	// One huge input plus many tiny distinct ones: proportional sampling
+	// draws from the huge input almost every time.
+	inputs := [][]byte{[]byte(strings.Repeat("dominant input content ", 4096))}
+	for i := 0; i < 40; i++ {
+		inputs = append(inputs, []byte(strings.Repeat(string(rune('A'+i)), 64)))
+	}
+	inputs = append(inputs, nil) // empty inputs contribute nothing
+
+	sample := makeSampleFullCoverage(inputs, fsstSampleTarget, fsstSampleLine)
+
+	// Every non-empty input must appear in the sample.
+	for i, input := range inputs {
+		if len(input) == 0 {
+			continue
+		}
+		found := false
+		for _, chunk := range sample {
+			if bytes.Contains(input, chunk) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("input %d unrepresented in full-coverage sample", i)
+		}
+	}
+
+	// The sample stays near target: coverage chunks plus the usual budget.
+	var sampleBytes int
+	for _, chunk := range sample {
+		sampleBytes += len(chunk)
+	}
+	if limit := 41*fsstSampleLine + 2*fsstSampleTarget; sampleBytes > limit {
+		t.Fatalf("sample is %d bytes, over limit %d", sampleBytes, limit)
+	}
+
+	// Determinism and end-to-end training with the option.
+	tbl := Train(inputs, WithFullCoverageSample(true))
+	again := Train(inputs, WithFullCoverageSample(true))
+	var a, b bytes.Buffer
+	if _, err := tbl.WriteTo(&a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := again.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Fatalf("full-coverage training is not deterministic")
+	}
+}
@@ -0,0 +1,44 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// goldenCorpus and goldenInput must match the data used to produce
+// testdata/golden_encode.fsst: a serialized table followed by the compressed
+// form of goldenInput. The test guards the cross-architecture stability
+// guarantee documented on Encode — the blob was produced once and must stay
+// byte-identical on every platform and Go version.
+var goldenCorpus = []byte(strings.Repeat(`{"level":"info","ts":1690000000,"msg":"request served","path":"/api/v1/items"}`+"\n", 64))
+var goldenInput = []byte(`{"level":"info","ts":1690000001,"msg":"request served","path":"/api/v1/users"}`)
+
+func TestEncodeGolden(t *testing.T) {
+	blob, err := os.ReadFile("testdata/golden_encode.fsst")
+	if err != nil {
+		t.Fatalf("read golden blob: %v", err)
+	}
+
+	r := bytes.NewReader(blob)
+	var stored Table
+	if _, err := stored.ReadFrom(r); err != nil {
+		t.Fatalf("read golden table: %v", err)
+	}
+	payload := blob[len(blob)-r.Len():]
+
+	// Re-training on the same corpus must reproduce the stored table's output.
+	tbl := Train([][]byte{goldenCorpus})
+	if got := tbl.Encode(nil, goldenInput); !bytes.Equal(got, payload) {
+		t.Fatalf("retrained table encodes %x, golden payload %x", got, payload)
+	}
+
+	// The deserialized table must produce the identical payload and roundtrip.
+	if got := stored.Encode(nil, goldenInput); !bytes.Equal(got, payload) {
+		t.Fatalf("stored table encodes %x, golden payload %x", got, payload)
+	}
+	if dec := stored.Decode(nil, payload); !bytes.Equal(dec, goldenInput) {
+		t.Fatalf("golden payload decodes to %q, want %q", dec, goldenInput)
+	}
+}
@@ -0,0 +1,55 @@
+package fsst
+
+// HasSymbol reports whether the exact byte sequence b (1-8 bytes) is a
+// learned symbol and, if so, returns its code. Unlike the encoder's
+// longest-match search this is pure membership — a sequence whose prefix is a
+// symbol but which itself is not reports false — which is what comparing
+// vocabularies across tables needs. It reads the encoder lookup structures
+// (byteCodes, shortCodes, the per-length hash tables), building them lazily
+// on first use, so each check is O(1); escape and other pseudo-entries in
+// those structures never count as members.
+func (t *Table) HasSymbol(b []byte) (code int, ok bool) {
+	if len(b) < 1 || len(b) > 8 {
+		return 0, false
+	}
+	t.ensureEncoder()
+
+	switch len(b) {
+	case 1:
+		packed := t.byteCodes[b[0]]
+		if packed&fsstCodeMask == fsstCodeMask {
+			return 0, false // escape pseudo-entry: no learned 1-byte symbol
+		}
+		return int(packed & fsstCodeMask), true
+	case 2:
+		packed := t.shortCodes[uint16(b[0])|uint16(b[1])<<8]
+		if packed>>fsstLenBits != 2 {
+			return 0, false // slot mirrors a 1-byte code: no 2-byte symbol
+		}
+		return int(packed & fsstCodeMask), true
+	}
+
+	sym := newSymbolFromBytes(b)
+	hashIndex := sym.hash() & (fsstHashTabSize - 1)
+	var entry symbol
+	switch len(b) {
+	case 3:
+		entry = t.hashTab3[hashIndex]
+	case 4:
+		entry = t.hashTab4[hashIndex]
+	case 5:
+		entry = t.hashTab5[hashIndex]
+	case 6:
+		entry = t.hashTab6[hashIndex]
+	case 7:
+		entry = t.hashTab7[hashIndex]
+	default:
+		entry = t.hashTab8[hashIndex]
+	}
+	// Entries store exactly their length's bytes (higher bytes zeroed), so a
+	// full value compare is an exact match test.
+	if entry.icl >= fsstICLFree || int(entry.length()) != len(b) || entry.val != sym.val {
+		return 0, false
+	}
+	return int(entry.code()), true
+}
@@ -0,0 +1,45 @@
+package fsst
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHasSymbol(t *testing.T) {
+	corpus := []byte(strings.Repeat("exact membership across vocabularies ", 100))
+	tbl := Train([][]byte{corpus})
+
+	// Every learned symbol is a member under its own code.
+	tbl.Range(func(s Symbol) bool {
+		code, ok := tbl.HasSymbol(s.Bytes)
+		if !ok {
+			t.Fatalf("learned symbol %q not reported as member", s.Bytes)
+		}
+		if code != s.Code {
+			t.Fatalf("symbol %q: HasSymbol code %d, want %d", s.Bytes, code, s.Code)
+		}
+		return true
+	})
+
+	// Membership is exact, not longest-match: extending a learned symbol by a
+	// byte that does not form a longer symbol must miss.
+	longest := tbl.SymbolOf(0) // finalize orders multi-byte symbols first
+	if len(longest) >= 2 && len(longest) < 8 {
+		if _, ok := tbl.HasSymbol(append(append([]byte{}, longest...), 0xFE)); ok {
+			t.Fatalf("extension of %q reported as member", longest)
+		}
+	}
+
+	// A byte absent from the corpus maps to an escape, not a symbol.
+	if _, ok := tbl.HasSymbol([]byte{0xFE}); ok {
+		t.Fatal("escaped byte reported as a learned 1-byte symbol")
+	}
+
+	// Length bounds.
+	if _, ok := tbl.HasSymbol(nil); ok {
+		t.Fatal("empty sequence reported as member")
+	}
+	if _, ok := tbl.HasSymbol([]byte("nine bytes")); ok {
+		t.Fatal("9-byte sequence reported as member")
+	}
+}
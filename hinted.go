@@ -0,0 +1,13 @@
+package fsst
+
+// DecodeHinted decompresses src like Decode but sizes the output buffer to
+// expectedLen up front, so callers that store the decoded length next to the
+// compressed bytes skip the doubling reallocations Decode goes through when
+// buf starts small. An underestimate is harmless — decoding falls back to the
+// usual growth path — and buf is reused when it already has the capacity.
+func (t *Table) DecodeHinted(buf, src []byte, expectedLen int) []byte {
+	if need := expectedLen + 8; cap(buf) < need {
+		buf = make([]byte, 0, need)
+	}
+	return t.Decode(buf, src)
+}
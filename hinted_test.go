@@ -0,0 +1,43 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeHinted(t *testing.T) {
+	input := []byte(strings.Repeat("length hinted decoding ", 500))
+	tbl := Train([][]byte{input})
+	comp := tbl.Encode(nil, input)
+	tbl.WarmDecoder()
+
+	got := tbl.DecodeHinted(nil, comp, len(input))
+	if !bytes.Equal(got, input) {
+		t.Fatalf("DecodeHinted mismatch")
+	}
+
+	// An accurate hint means a single allocation for the whole decode.
+	if allocs := testing.AllocsPerRun(10, func() {
+		tbl.DecodeHinted(nil, comp, len(input))
+	}); allocs > 1 {
+		t.Fatalf("accurate hint still allocated %v times", allocs)
+	}
+
+	// Underestimates fall back to growth, overestimates just waste a little.
+	for _, hint := range []int{0, 10, len(input) / 2, 2 * len(input)} {
+		if got := tbl.DecodeHinted(nil, comp, hint); !bytes.Equal(got, input) {
+			t.Fatalf("hint %d: mismatch", hint)
+		}
+	}
+
+	// A caller-provided buffer with enough capacity is reused.
+	buf := make([]byte, 0, len(input)+8)
+	got = tbl.DecodeHinted(buf, comp, len(input))
+	if !bytes.Equal(got, input) {
+		t.Fatalf("mismatch with reused buffer")
+	}
+	if &got[0] != &buf[:1][0] {
+		t.Fatalf("adequate buffer was not reused")
+	}
+}
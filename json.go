@@ -0,0 +1,82 @@
+package fsst
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// tableJSON is the JSON view of a Table. Symbols are hex strings in code
+// order; their lengths are implied by the hex length, so the wire form stays
+// self-describing for non-Go consumers.
+type tableJSON struct {
+	NSymbols  uint16   `json:"nSymbols"`
+	SuffixLim uint16   `json:"suffixLim"`
+	Symbols   []string `json:"symbols"`
+}
+
+// MarshalJSON implements json.Marshaler. It emits a human-inspectable view of
+// the finalized table: the learned symbols as hex strings (in code order),
+// plus nSymbols and suffixLim. Use MarshalBinary for the compact format.
+// Extended-mode, macro, backref, order-preserving, and frequency-reassigned
+// tables are rejected with ErrBadFormat: the view carries no mode state, so
+// the unmarshalled table would silently encode differently and fail to decode
+// the original's streams — the same reasoning as WriteToCompressed.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 || t.ordered || t.freqRemap != nil {
+		return nil, ErrBadFormat
+	}
+	view := tableJSON{
+		NSymbols:  t.nSymbols,
+		SuffixLim: t.suffixLim,
+		Symbols:   make([]string, t.nSymbols),
+	}
+	for i := range int(t.nSymbols) {
+		sym := t.symbols[i]
+		symbolLength := int(sym.length())
+		raw := make([]byte, symbolLength)
+		for byteIdx := range symbolLength {
+			raw[byteIdx] = byte(sym.val >> (8 * byteIdx))
+		}
+		view.Symbols[i] = hex.EncodeToString(raw)
+	}
+	return json.Marshal(view)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and rebuilds a Table from the
+// MarshalJSON representation. The restored table produces identical encoded
+// output to the original.
+func (t *Table) UnmarshalJSON(data []byte) error {
+	var view tableJSON
+	if err := json.Unmarshal(data, &view); err != nil {
+		return err
+	}
+	if int(view.NSymbols) > fsstMaxSymbols {
+		return fmt.Errorf("fsst: nSymbols %d exceeds maximum %d", view.NSymbols, fsstMaxSymbols)
+	}
+	if len(view.Symbols) != int(view.NSymbols) {
+		return fmt.Errorf("fsst: symbol count %d does not match nSymbols %d", len(view.Symbols), view.NSymbols)
+	}
+	*t = *newTable() // reset
+	t.nSymbols = view.NSymbols
+	t.suffixLim = view.SuffixLim
+	for i, hexSym := range view.Symbols {
+		raw, err := hex.DecodeString(hexSym)
+		if err != nil {
+			return fmt.Errorf("fsst: symbol %d: %w", i, err)
+		}
+		if len(raw) < 1 || len(raw) > 8 {
+			return fmt.Errorf("fsst: symbol %d has invalid length %d", i, len(raw))
+		}
+		var symbolValue uint64
+		for byteIdx := range raw {
+			symbolValue |= uint64(raw[byteIdx]) << (8 * byteIdx)
+		}
+		sym := symbol{val: symbolValue}
+		sym.setCodeLen(uint32(i), uint32(len(raw)))
+		t.symbols[i] = sym
+		t.lenHisto[len(raw)-1]++
+	}
+	t.accelReady = false
+	return nil
+}
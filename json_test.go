@@ -0,0 +1,71 @@
+package fsst
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSONRoundtrip(t *testing.T) {
+	input := []byte("the quick brown fox jumps over the lazy dog, repeatedly and deterministically")
+	tbl := Train([][]byte{input})
+
+	data, err := json.Marshal(tbl)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var tbl2 Table
+	if err := json.Unmarshal(data, &tbl2); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	comp1 := tbl.EncodeAll(input)
+	comp2 := tbl2.EncodeAll(input)
+	if !bytes.Equal(comp1, comp2) {
+		t.Fatalf("JSON roundtrip changed compressed output")
+	}
+	got := tbl2.DecodeAll(comp2)
+	if !bytes.Equal(got, input) {
+		t.Fatalf("JSON roundtrip decode mismatch")
+	}
+}
+
+func TestMarshalJSONRejectsModes(t *testing.T) {
+	// The JSON view carries no mode state, so a table whose output depends on
+	// one must refuse to marshal rather than round-trip into a plain table
+	// that encodes differently.
+	corpus := [][]byte{[]byte(strings.Repeat("mode-bound tables reject json ", 100))}
+	remapped := Train(corpus)
+	if err := remapped.AssignCodesByFrequency(corpus); err != nil {
+		t.Fatal(err)
+	}
+	for _, tbl := range []*Table{
+		Train(corpus, WithExtendedCodes(true)),
+		Train(corpus, WithMacros(true)),
+		Train(corpus, WithBackrefs(64)),
+		TrainOrderPreserving(corpus),
+		remapped,
+	} {
+		if _, err := json.Marshal(tbl); !errors.Is(err, ErrBadFormat) {
+			t.Fatalf("mode-bound table marshalled: err=%v, want ErrBadFormat", err)
+		}
+	}
+}
+
+func TestUnmarshalJSONRejectsBadSymbols(t *testing.T) {
+	cases := []string{
+		`{"nSymbols":1,"suffixLim":0,"symbols":[]}`,                     // count mismatch
+		`{"nSymbols":1,"suffixLim":0,"symbols":["zz"]}`,                 // bad hex
+		`{"nSymbols":1,"suffixLim":0,"symbols":[""]}`,                   // empty symbol
+		`{"nSymbols":1,"suffixLim":0,"symbols":["000102030405060708"]}`, // 9 bytes
+	}
+	for _, c := range cases {
+		var tbl Table
+		if err := json.Unmarshal([]byte(c), &tbl); err == nil {
+			t.Fatalf("expected error for %s", c)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package fsst
+
+import (
+	"errors"
+	"math"
+)
+
+// MaxEncodeLen is the largest input length a single Encode call accepts.
+// Encode sizes its worst-case output as 2*len(input)+fsstOutputPadding, and
+// this bound keeps that expression from overflowing int — on 32-bit
+// platforms it works out to just under 1 GiB. Larger data should go through
+// EncodeStream or EncodeChunked, which process bounded pieces.
+const MaxEncodeLen = (math.MaxInt - fsstOutputPadding) / 2
+
+// ErrTooLarge is returned by EncodeLimited when the input exceeds
+// MaxEncodeLen.
+var ErrTooLarge = errors.New("fsst: input exceeds MaxEncodeLen")
+
+// EncodeLimited compresses input like Encode but returns ErrTooLarge instead
+// of panicking when the input is too large for a single call. Callers
+// concatenating untrusted or unbounded blobs get a defined failure mode and
+// can fall back to EncodeStream.
+func (t *Table) EncodeLimited(buf, input []byte) ([]byte, error) {
+	if len(input) > MaxEncodeLen {
+		return buf, ErrTooLarge
+	}
+	return t.Encode(buf, input), nil
+}
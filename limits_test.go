@@ -0,0 +1,27 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodeLimited(t *testing.T) {
+	input := []byte(strings.Repeat("bounded encoding ", 200))
+	tbl := Train([][]byte{input})
+
+	// Within the limit the checked variant is Encode plus a length test.
+	got, err := tbl.EncodeLimited(nil, input)
+	if err != nil {
+		t.Fatalf("EncodeLimited: %v", err)
+	}
+	if !bytes.Equal(got, tbl.Encode(nil, input)) {
+		t.Fatalf("EncodeLimited output differs from Encode")
+	}
+
+	// The worst-case output expression must not overflow for any accepted
+	// length; at the bound it lands exactly on the largest positive int.
+	if worst := 2*MaxEncodeLen + fsstOutputPadding; worst <= 0 {
+		t.Fatalf("worst-case size overflows at MaxEncodeLen: %d", worst)
+	}
+}
@@ -0,0 +1,88 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// DecodeLossy decompresses src while tolerating corruption: instead of
+// stopping at the first anomaly it records an error with the byte offset,
+// skips the bad code, and keeps going. Anomalies are a truncated trailing
+// escape, codes outside the learned symbol range, and (in extended, macro, or
+// backref mode) truncated prefixes, dangling macro indexes, or back-reference
+// distances past the decoded output. Intended for forensic
+// recovery of partially corrupted archives, not for hot decode paths.
+func (t *Table) DecodeLossy(src []byte) ([]byte, []error) {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	var (
+		out  = make([]byte, 0, len(src)*4+8)
+		errs []error
+		tmp  [8]byte
+	)
+	for srcPos := 0; srcPos < len(src); {
+		at := srcPos
+		code := uint16(src[srcPos])
+		srcPos++
+		switch {
+		case code == fsstEscapeCode:
+			if srcPos >= len(src) {
+				errs = append(errs, fmt.Errorf("fsst: truncated escape at offset %d", at))
+				continue
+			}
+			out = append(out, src[srcPos])
+			srcPos++
+		case t.extended:
+			if code == fsstExtendedPrefix {
+				if srcPos >= len(src) {
+					errs = append(errs, fmt.Errorf("fsst: truncated extended code at offset %d", at))
+					continue
+				}
+				code = fsstExtendedPrefix + uint16(src[srcPos])
+				srcPos++
+			}
+			if int(code) >= len(t.decLenExt) {
+				errs = append(errs, fmt.Errorf("fsst: unknown code %d at offset %d", code, at))
+				continue
+			}
+			binary.LittleEndian.PutUint64(tmp[:], t.decSymbolExt[code])
+			out = append(out, tmp[:t.decLenExt[code]]...)
+		case t.hasMacros && code >= t.macroBase:
+			idx := code - t.macroBase
+			if int(idx) >= len(t.macros) {
+				errs = append(errs, fmt.Errorf("fsst: unknown macro code %d at offset %d", code, at))
+				continue
+			}
+			for _, c := range t.macros[idx] {
+				binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[c])
+				out = append(out, tmp[:t.decLen[c]]...)
+			}
+		case t.backrefWindow > 0 && code == t.nSymbols:
+			if srcPos+1 >= len(src) {
+				errs = append(errs, fmt.Errorf("fsst: truncated back-reference at offset %d", at))
+				srcPos = len(src)
+				continue
+			}
+			dist := int(src[srcPos])
+			length := int(src[srcPos+1])
+			srcPos += 2
+			if dist < 1 || dist > len(out) {
+				errs = append(errs, fmt.Errorf("fsst: back-reference distance %d at offset %d exceeds decoded output", dist, at))
+				continue
+			}
+			for range length {
+				out = append(out, out[len(out)-dist])
+			}
+		case code >= t.nSymbols:
+			errs = append(errs, fmt.Errorf("fsst: unknown code %d at offset %d", code, at))
+		default:
+			binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[code])
+			out = append(out, tmp[:t.decLen[code]]...)
+		}
+	}
+	return out, errs
+}
@@ -0,0 +1,62 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeLossyCleanInput(t *testing.T) {
+	input := []byte(strings.Repeat("clean streams decode without errors ", 20))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+
+	got, errs := tbl.DecodeLossy(compressed)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors on clean input: %v", errs)
+	}
+	if !bytes.Equal(got, input) {
+		t.Fatalf("lossy decode of clean input mismatch")
+	}
+}
+
+func TestDecodeLossyTrailingEscape(t *testing.T) {
+	input := []byte(strings.Repeat("recover what you can ", 20))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+	truncated := append(bytes.Clone(compressed), fsstEscapeCode)
+
+	got, errs := tbl.DecodeLossy(truncated)
+	if len(errs) != 1 {
+		t.Fatalf("errors = %v, want exactly one truncated-escape error", errs)
+	}
+	if want := len(compressed); !strings.Contains(errs[0].Error(), "offset") || !strings.Contains(errs[0].Error(), "escape") {
+		t.Errorf("error %q should mention the escape and its offset %d", errs[0], want)
+	}
+	if !bytes.Equal(got, input) {
+		t.Fatalf("recovered output should match the intact prefix")
+	}
+}
+
+func TestDecodeLossyUnknownCode(t *testing.T) {
+	input := []byte(strings.Repeat("abcabcabc ", 30))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+	if tbl.nSymbols >= 254 {
+		t.Skip("no spare code to corrupt with")
+	}
+
+	// Inject a code the table never assigned mid-stream.
+	bad := uint8(253)
+	corrupted := append(bytes.Clone(compressed[:4]), bad)
+	corrupted = append(corrupted, compressed[4:]...)
+
+	got, errs := tbl.DecodeLossy(corrupted)
+	if len(errs) == 0 {
+		t.Fatalf("expected an unknown-code error")
+	}
+	// Everything around the bad byte still decodes.
+	if len(got) < len(input)-16 {
+		t.Fatalf("recovered only %d of %d bytes", len(got), len(input))
+	}
+}
@@ -0,0 +1,207 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"io"
+	"sort"
+)
+
+// Macro codes extend the symbol length limit past 8 bytes: after regular
+// training, pairs of codes that frequently co-occur in encoded output are
+// assigned spare codes in [nSymbols..255). The encoder rewrites each pair to
+// its macro code and the decoder expands the macro back into the two
+// underlying symbols, so a macro effectively behaves like one symbol of up to
+// 16 bytes. Enabled via WithMacros; not available together with extended
+// codes (macros live in the spare single-byte code space).
+
+// fsstMacroMinCount is the minimum number of co-occurrences in the training
+// sample for a code pair to earn a macro code.
+const fsstMacroMinCount = 4
+
+// buildMacros scans the sample as encoded output and assigns spare codes to
+// the most frequent adjacent code pairs. Called after finalize; the table is
+// otherwise complete.
+func (t *Table) buildMacros(sample [][]byte) {
+	spare := fsstMaxSymbols - int(t.nSymbols)
+	if spare <= 0 {
+		return
+	}
+
+	// Count adjacent plain-code pairs across the encoded sample.
+	pairCounts := make(map[[2]uint16]int)
+	for i := range sample {
+		comp := t.Encode(nil, sample[i])
+		prev := -1
+		for pos := 0; pos < len(comp); pos++ {
+			code := comp[pos]
+			if code == fsstEscapeCode {
+				pos++ // skip literal; escapes break pair chains
+				prev = -1
+				continue
+			}
+			if prev >= 0 {
+				pairCounts[[2]uint16{uint16(prev), uint16(code)}]++
+			}
+			prev = int(code)
+		}
+	}
+
+	// Rank pairs by count (descending), deterministic tiebreak on pair value.
+	type rankedPair struct {
+		pair  [2]uint16
+		count int
+	}
+	ranked := make([]rankedPair, 0, len(pairCounts))
+	for pair, count := range pairCounts {
+		if count >= fsstMacroMinCount {
+			ranked = append(ranked, rankedPair{pair, count})
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].count != ranked[j].count {
+			return ranked[i].count > ranked[j].count
+		}
+		if ranked[i].pair[0] != ranked[j].pair[0] {
+			return ranked[i].pair[0] < ranked[j].pair[0]
+		}
+		return ranked[i].pair[1] < ranked[j].pair[1]
+	})
+	if len(ranked) > spare {
+		ranked = ranked[:spare]
+	}
+	if len(ranked) == 0 {
+		return
+	}
+
+	t.macroBase = t.nSymbols
+	t.macros = make([][2]uint16, len(ranked))
+	t.macroLookup = make(map[[2]uint16]uint16, len(ranked))
+	for i := range ranked {
+		t.macros[i] = ranked[i].pair
+		t.macroLookup[ranked[i].pair] = t.macroBase + uint16(i)
+	}
+	t.hasMacros = true
+}
+
+// decodeMacros decompresses a macro-enabled code stream: macro codes expand
+// into their underlying pair of symbols, everything else decodes as usual.
+func (t *Table) decodeMacros(buf, src []byte) []byte {
+	t.ensureDecoder()
+
+	if buf == nil {
+		buf = make([]byte, 0, len(src)*4+8)
+	} else {
+		buf = buf[:0] // Reset length but keep capacity
+	}
+
+	var tmp [8]byte
+	for srcPos := 0; srcPos < len(src); {
+		code := src[srcPos]
+		srcPos++
+		switch {
+		case code == fsstEscapeCode:
+			if srcPos >= len(src) {
+				return buf
+			}
+			buf = append(buf, src[srcPos])
+			srcPos++
+		case uint16(code) >= t.macroBase:
+			idx := uint16(code) - t.macroBase
+			if int(idx) >= len(t.macros) {
+				continue
+			}
+			pair := t.macros[idx]
+			for _, c := range pair {
+				binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[c])
+				buf = append(buf, tmp[:t.decLen[c]]...)
+			}
+		default:
+			binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[code])
+			buf = append(buf, tmp[:t.decLen[code]]...)
+		}
+	}
+	return buf
+}
+
+// writeToMacros serializes a macro-enabled Table: the plain layout with the
+// macro format flag, followed by a trailer of macro pairs in macro-code order.
+func (t *Table) writeToMacros(w io.Writer) (int64, error) {
+	n, err := t.writeToPlain(w, fsstFormatMacros)
+	if err != nil {
+		return n, err
+	}
+	trailer := make([]byte, 1+4*len(t.macros))
+	trailer[0] = byte(len(t.macros))
+	for i, pair := range t.macros {
+		binary.LittleEndian.PutUint16(trailer[1+4*i:], pair[0])
+		binary.LittleEndian.PutUint16(trailer[3+4*i:], pair[1])
+	}
+	nn, err := w.Write(trailer)
+	return n + int64(nn), err
+}
+
+// readMacroTrailer finishes deserializing a macro-enabled Table after the
+// symbol block has been read.
+func (t *Table) readMacroTrailer(r io.Reader, n int64) (int64, error) {
+	var cnt [1]byte
+	if _, err := io.ReadFull(r, cnt[:]); err != nil {
+		return n, err
+	}
+	n++
+	count := int(cnt[0])
+	if int(t.nSymbols)+count > fsstMaxSymbols {
+		return n, ErrBadFormat
+	}
+	pairBytes := make([]byte, 4*count)
+	if _, err := io.ReadFull(r, pairBytes); err != nil {
+		return n, err
+	}
+	n += int64(len(pairBytes))
+	t.macroBase = t.nSymbols
+	t.macros = make([][2]uint16, count)
+	t.macroLookup = make(map[[2]uint16]uint16, count)
+	for i := range count {
+		a := binary.LittleEndian.Uint16(pairBytes[4*i:])
+		b := binary.LittleEndian.Uint16(pairBytes[4*i+2:])
+		if a >= t.nSymbols || b >= t.nSymbols {
+			return n, ErrBadFormat
+		}
+		t.macros[i] = [2]uint16{a, b}
+		t.macroLookup[[2]uint16{a, b}] = t.macroBase + uint16(i)
+	}
+	t.hasMacros = count > 0
+	return n, nil
+}
+
+// rewriteMacros replaces adjacent code pairs in an encoded stream with their
+// macro codes, in place (output only shrinks). Greedy left-to-right.
+func (t *Table) rewriteMacros(comp []byte) []byte {
+	out := 0
+	for pos := 0; pos < len(comp); {
+		code := comp[pos]
+		if code == fsstEscapeCode {
+			// Escape sequences pass through untouched.
+			comp[out] = code
+			if pos+1 < len(comp) {
+				comp[out+1] = comp[pos+1]
+				out += 2
+			} else {
+				out++
+			}
+			pos += 2
+			continue
+		}
+		if pos+1 < len(comp) && comp[pos+1] != fsstEscapeCode {
+			if macro, ok := t.macroLookup[[2]uint16{uint16(code), uint16(comp[pos+1])}]; ok {
+				comp[out] = uint8(macro)
+				out++
+				pos += 2
+				continue
+			}
+		}
+		comp[out] = code
+		out++
+		pos++
+	}
+	return comp[:out]
+}
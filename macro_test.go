@@ -0,0 +1,97 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// macroCorpus repeats long tokens (>8 bytes) so macro codes have pairs worth
+// learning.
+func macroCorpus() [][]byte {
+	var inputs [][]byte
+	for i := 0; i < 200; i++ {
+		inputs = append(inputs, []byte("https://www.example.com/index.html"))
+		inputs = append(inputs, []byte("application/json; charset=utf-8"))
+	}
+	return inputs
+}
+
+func TestMacroRoundtrip(t *testing.T) {
+	inputs := macroCorpus()
+	table := Train(inputs, WithMacros(true))
+
+	if !table.hasMacros {
+		t.Fatal("expected macro codes to be assigned on repetitive corpus")
+	}
+	for _, input := range inputs[:4] {
+		compressed := table.Encode(nil, input)
+		decompressed := table.Decode(nil, compressed)
+		if !bytes.Equal(decompressed, input) {
+			t.Fatalf("roundtrip mismatch: got %q, want %q", decompressed, input)
+		}
+		if got := table.DecodedLen(compressed); got != len(input) {
+			t.Errorf("DecodedLen = %d, want %d", got, len(input))
+		}
+	}
+}
+
+func TestMacroImprovesRatio(t *testing.T) {
+	inputs := macroCorpus()
+	plain := Train(inputs)
+	macro := Train(inputs, WithMacros(true))
+
+	input := []byte(strings.Repeat("https://www.example.com/index.html\n", 50))
+	plainLen := len(plain.Encode(nil, input))
+	macroLen := len(macro.Encode(nil, input))
+	if macroLen >= plainLen {
+		t.Errorf("macro encoding %d bytes, plain %d; expected macros to shrink output", macroLen, plainLen)
+	}
+}
+
+func TestMacroSerializationRoundtrip(t *testing.T) {
+	inputs := macroCorpus()
+	table := Train(inputs, WithMacros(true))
+	if !table.hasMacros {
+		t.Fatal("expected macro codes to be assigned")
+	}
+
+	var buf bytes.Buffer
+	if _, err := table.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	restored := &Table{}
+	if _, err := restored.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom failed: %v", err)
+	}
+	if !restored.hasMacros || len(restored.macros) != len(table.macros) {
+		t.Fatalf("restored table has %d macros, want %d", len(restored.macros), len(table.macros))
+	}
+
+	input := []byte("https://www.example.com/index.html application/json; charset=utf-8")
+	want := table.Encode(nil, input)
+	got := restored.Encode(nil, input)
+	if !bytes.Equal(got, want) {
+		t.Fatal("restored table produces different encoding")
+	}
+	if !bytes.Equal(restored.Decode(nil, want), input) {
+		t.Fatal("restored table fails to decode original encoding")
+	}
+}
+
+func TestMacroDecodeToWriter(t *testing.T) {
+	inputs := macroCorpus()
+	table := Train(inputs, WithMacros(true))
+
+	input := []byte(strings.Repeat("https://www.example.com/index.html\n", 300))
+	compressed := table.Encode(nil, input)
+
+	var out bytes.Buffer
+	n, err := table.DecodeToWriter(&out, compressed)
+	if err != nil {
+		t.Fatalf("DecodeToWriter failed: %v", err)
+	}
+	if n != int64(len(input)) || !bytes.Equal(out.Bytes(), input) {
+		t.Fatalf("DecodeToWriter wrote %d bytes, want %d", n, len(input))
+	}
+}
@@ -0,0 +1,62 @@
+package fsst
+
+// EncodeMany encodes each input into the corresponding dsts entry and returns
+// the results. dsts may be nil or shorter than inputs; missing or undersized
+// destinations are allocated exactly as in Encode. The encoder is warmed up
+// once for the whole batch, amortizing the lazy-init check and strategy
+// selection over every record — worthwhile when encoding many small values in
+// a tight loop.
+func (t *Table) EncodeMany(dsts [][]byte, inputs [][]byte) [][]byte {
+	t.ensureEncoder()
+
+	if len(dsts) < len(inputs) {
+		grown := make([][]byte, len(inputs))
+		copy(grown, dsts)
+		dsts = grown
+	}
+	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+	for i, input := range inputs {
+		dsts[i] = t.encodeOne(dsts[i], input, byteLim)
+	}
+	return dsts[:len(inputs)]
+}
+
+// encodeOne is the per-record body of Encode with the warmup hoisted out.
+func (t *Table) encodeOne(buf, input []byte, byteLim uint8) []byte {
+	if t.backrefWindow > 0 {
+		return t.encodeBackrefs(buf, input)
+	}
+	if t.ordered {
+		return t.encodeOrdered(buf, input)
+	}
+	if cap(buf) < 2*len(input)+fsstOutputPadding {
+		buf = make([]byte, 2*len(input)+fsstOutputPadding)
+	} else {
+		buf = buf[:cap(buf)]
+	}
+
+	outPos := 0
+	inputLen := len(input)
+	chunkLen := t.chunkLen()
+	position := 0
+	for position+8 <= inputLen {
+		end := min(position+chunkLen, inputLen-7) - position
+		var consumed int
+		outPos, consumed = t.encodeChunk(buf, outPos, input[position:], end, end+7, byteLim)
+		position += consumed
+	}
+	if position < inputLen {
+		chunkBuf := t.encBuf
+		tailLen := inputLen - position
+		copy(chunkBuf[:tailLen], input[position:])
+		clear(chunkBuf[tailLen : tailLen+8])
+		outPos, _ = t.encodeChunk(buf, outPos, chunkBuf, tailLen, tailLen, byteLim)
+	}
+	if t.hasMacros {
+		return t.rewriteMacros(buf[:outPos])
+	}
+	if t.freqRemap != nil {
+		t.remapWireCodes(buf[:outPos])
+	}
+	return buf[:outPos]
+}
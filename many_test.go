@@ -0,0 +1,75 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodeManyMatchesEncode(t *testing.T) {
+	corpus := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	tbl := Train([][]byte{corpus})
+
+	inputs := [][]byte{
+		corpus[:100],
+		[]byte("short"),
+		{},
+		corpus[37:400],
+	}
+
+	got := tbl.EncodeMany(nil, inputs)
+	if len(got) != len(inputs) {
+		t.Fatalf("EncodeMany returned %d results, want %d", len(got), len(inputs))
+	}
+	for i := range inputs {
+		want := tbl.Encode(nil, inputs[i])
+		if !bytes.Equal(got[i], want) {
+			t.Fatalf("input %d: EncodeMany output differs from Encode", i)
+		}
+	}
+
+	// Preallocated destinations are reused in place.
+	dsts := make([][]byte, len(inputs))
+	for i := range dsts {
+		dsts[i] = make([]byte, 2*len(inputs[i])+fsstOutputPadding)
+	}
+	reused := tbl.EncodeMany(dsts, inputs)
+	for i := range inputs {
+		want := tbl.Encode(nil, inputs[i])
+		if !bytes.Equal(reused[i], want) {
+			t.Fatalf("input %d: EncodeMany with preallocated dst differs", i)
+		}
+	}
+}
+
+func BenchmarkEncodeMany(b *testing.B) {
+	corpus := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	tbl := Train([][]byte{corpus})
+
+	// Many small records, the case batch warmup targets.
+	inputs := make([][]byte, 256)
+	var total int64
+	for i := range inputs {
+		inputs[i] = corpus[i*7%1000 : i*7%1000+40]
+		total += 40
+	}
+	dsts := make([][]byte, len(inputs))
+	for i := range dsts {
+		dsts[i] = make([]byte, 2*len(inputs[i])+fsstOutputPadding)
+	}
+
+	b.Run("loop", func(b *testing.B) {
+		b.SetBytes(total)
+		for i := 0; i < b.N; i++ {
+			for j, input := range inputs {
+				dsts[j] = tbl.Encode(dsts[j], input)
+			}
+		}
+	})
+	b.Run("many", func(b *testing.B) {
+		b.SetBytes(total)
+		for i := 0; i < b.N; i++ {
+			dsts = tbl.EncodeMany(dsts, inputs)
+		}
+	})
+}
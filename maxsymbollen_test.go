@@ -0,0 +1,43 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWithMaxSymbolLength(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("GET /api/v2/users HTTP/1.1\n", 200))}
+	for _, cap := range []int{2, 3, 4} {
+		tbl := Train(corpus, WithMaxSymbolLength(cap))
+
+		// The finalized histogram must respect the cap.
+		for i := cap; i < 8; i++ {
+			if tbl.lenHisto[i] != 0 {
+				t.Fatalf("cap %d: table retained %d symbols of length %d", cap, tbl.lenHisto[i], i+1)
+			}
+		}
+		for _, sym := range tbl.Symbols() {
+			if len(sym) > cap {
+				t.Fatalf("cap %d: table retained %d-byte symbol %q", cap, len(sym), sym)
+			}
+		}
+
+		for _, input := range [][]byte{corpus[0], []byte("entirely \x00 unrelated \xff bytes")} {
+			comp := tbl.Encode(nil, input)
+			if got := tbl.Decode(nil, comp); !bytes.Equal(got, input) {
+				t.Fatalf("cap %d: roundtrip mismatch: got %q, want %q", cap, got, input)
+			}
+		}
+	}
+}
+
+func TestWithMaxSymbolLengthDefault(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("status=ok level=info ", 200))}
+	plain := Train(corpus)
+	for _, n := range []int{0, 1, 8, 9} {
+		if !Train(corpus, WithMaxSymbolLength(n)).Equal(plain) {
+			t.Fatalf("WithMaxSymbolLength(%d) changed training", n)
+		}
+	}
+}
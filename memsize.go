@@ -0,0 +1,26 @@
+package fsst
+
+import "unsafe"
+
+// MemorySize returns the approximate number of resident bytes held by the
+// table, for memory-based cache accounting and eviction. The fixed arrays
+// (shortCodes, hashTab and friends, symbols, decoder arrays) dominate and are
+// counted via the struct size; lazily allocated structures — the encode
+// scratch buffers and the extended decoder arrays — are only counted once
+// they exist, so the result grows after the first Encode or Decode call.
+// Map overhead for macro lookup is estimated from the bucket count.
+func (t *Table) MemorySize() int {
+	size := int(unsafe.Sizeof(*t))
+	size += cap(t.encBuf)
+	size += cap(t.lenBuf)
+	size += cap(t.frameBuf)
+	size += cap(t.decLenExt)
+	size += cap(t.decSymbolExt) * 8
+	size += cap(t.macros) * 4
+	if t.macroLookup != nil {
+		// Each map entry holds a [2]uint16 key and uint16 value; round up to
+		// account for bucket headers and load factor.
+		size += len(t.macroLookup) * 16
+	}
+	return size
+}
@@ -0,0 +1,25 @@
+package fsst
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestMemorySizeGrowsAfterEncode(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("memory accounting test ", 50))})
+
+	before := tbl.MemorySize()
+	if before < int(unsafe.Sizeof(*tbl)) {
+		t.Fatalf("MemorySize %d smaller than struct size %d", before, unsafe.Sizeof(*tbl))
+	}
+
+	tbl.Encode(nil, []byte("memory accounting test"))
+	after := tbl.MemorySize()
+	if after <= before {
+		t.Fatalf("MemorySize did not grow after Encode: before %d, after %d", before, after)
+	}
+	if after-before < cap(tbl.encBuf) {
+		t.Fatalf("growth %d does not cover encBuf capacity %d", after-before, cap(tbl.encBuf))
+	}
+}
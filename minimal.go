@@ -0,0 +1,74 @@
+package fsst
+
+import "sort"
+
+// TrainMinimal trains a full table, then greedily prunes the lowest-gain
+// symbols for as long as the estimated compression ratio stays within
+// maxRatioLoss of the full table's (e.g. 0.05 allows a 5% relative ratio
+// loss). For tiny records the serialized table dominates total stored size,
+// so trading a sliver of ratio for a much smaller table wins overall. The
+// returned table is pruned and finalized; maxRatioLoss <= 0 returns the full
+// table unchanged.
+func TrainMinimal(inputs [][]byte, maxRatioLoss float64) *Table {
+	full := Train(inputs)
+	if maxRatioLoss <= 0 || full.nSymbols == 0 {
+		return full
+	}
+
+	sample := makeSample(inputs)
+	baseRatio := full.EstimateRatio(sample)
+	limit := baseRatio * (1 + maxRatioLoss)
+
+	// Rank symbols by realized gain (usage × length) over the sample, the
+	// same score training optimizes, with the symbol value as deterministic
+	// tiebreak.
+	usage := make([]int, full.nSymbols)
+	for i := range sample {
+		comp := full.Encode(nil, sample[i])
+		for pos := 0; pos < len(comp); pos++ {
+			if comp[pos] == fsstEscapeCode {
+				pos++
+				continue
+			}
+			if int(comp[pos]) < len(usage) {
+				usage[comp[pos]]++
+			}
+		}
+	}
+	order := make([]int, full.nSymbols)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		ga := usage[a] * int(full.symbols[a].length())
+		gb := usage[b] * int(full.symbols[b].length())
+		if ga != gb {
+			return ga < gb
+		}
+		return full.symbols[a].val < full.symbols[b].val
+	})
+
+	// Drop symbols lowest-gain first, keeping the last table that fits the
+	// ratio budget.
+	best := full
+	keep := make(map[int]bool, full.nSymbols)
+	for _, code := range order {
+		keep[code] = true
+	}
+	for _, code := range order[:len(order)-1] {
+		delete(keep, code)
+		pruned := newTable()
+		for c := range int(full.nSymbols) {
+			if keep[c] {
+				pruned.addSymbol(full.symbols[c])
+			}
+		}
+		pruned.finalize()
+		if pruned.EstimateRatio(sample) > limit {
+			break
+		}
+		best = pruned
+	}
+	return best
+}
@@ -0,0 +1,49 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func minimalCorpus() [][]byte {
+	var inputs [][]byte
+	for i := 0; i < 300; i++ {
+		inputs = append(inputs, []byte("GET /api/v1/users/42 HTTP/1.1 host=example.com "))
+		inputs = append(inputs, []byte("POST /api/v1/orders HTTP/1.1 host=example.com "))
+	}
+	return inputs
+}
+
+func TestTrainMinimalPrunes(t *testing.T) {
+	inputs := minimalCorpus()
+	full := Train(inputs)
+	minimal := TrainMinimal(inputs, 0.10)
+
+	if minimal.nSymbols > full.nSymbols {
+		t.Fatalf("minimal table has %d symbols, full %d", minimal.nSymbols, full.nSymbols)
+	}
+
+	sample := makeSample(inputs)
+	fullRatio := full.EstimateRatio(sample)
+	minRatio := minimal.EstimateRatio(sample)
+	if minRatio > fullRatio*1.10+1e-9 {
+		t.Fatalf("minimal ratio %f exceeds budget over full ratio %f", minRatio, fullRatio)
+	}
+
+	// The pruned table still roundtrips.
+	input := []byte(strings.Repeat("GET /api/v1/users/42 HTTP/1.1 ", 10))
+	comp := minimal.Encode(nil, input)
+	if got := minimal.Decode(nil, comp); !bytes.Equal(got, input) {
+		t.Fatalf("pruned table roundtrip mismatch")
+	}
+}
+
+func TestTrainMinimalZeroBudget(t *testing.T) {
+	inputs := minimalCorpus()
+	full := Train(inputs)
+	same := TrainMinimal(inputs, 0)
+	if same.nSymbols != full.nSymbols {
+		t.Fatalf("zero budget should keep the full table")
+	}
+}
@@ -0,0 +1,37 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWithMinSymbolLength(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("GET /api/v2/users HTTP/1.1\n", 200))}
+	tbl := Train(corpus, WithMinSymbolLength(2))
+
+	for _, sym := range tbl.Symbols() {
+		if len(sym) < 2 {
+			t.Fatalf("table retained %d-byte symbol %q despite minimum of 2", len(sym), sym)
+		}
+	}
+
+	// Uncovered bytes fall back to escapes, so roundtrips still work —
+	// including on input the table never saw.
+	for _, input := range [][]byte{corpus[0], []byte("entirely \x00 unrelated \xff bytes")} {
+		comp := tbl.Encode(nil, input)
+		if got := tbl.Decode(nil, comp); !bytes.Equal(got, input) {
+			t.Fatalf("roundtrip mismatch: got %q, want %q", got, input)
+		}
+	}
+}
+
+func TestWithMinSymbolLengthDefault(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("status=ok level=info ", 200))}
+	plain := Train(corpus)
+	for _, n := range []int{0, 1} {
+		if !Train(corpus, WithMinSymbolLength(n)).Equal(plain) {
+			t.Fatalf("WithMinSymbolLength(%d) changed training", n)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package fsst
+
+// DecodeMmap decompresses src and returns a newly allocated result. It is
+// documented separately from Decode for callers passing sub-slices of
+// read-only memory-mapped files: every decode path indexes src strictly below
+// len(src) — a truncated escape or extended-code prefix at the end of the
+// stream stops cleanly instead of reading the following byte — and src is
+// never written, so a crafted or corrupted stream cannot fault on the mapped
+// region. The unsafe unaligned loads in this package are encode-side only.
+// FuzzDecodeMmap exercises arbitrary streams against these guarantees.
+func (t *Table) DecodeMmap(src []byte) []byte {
+	return t.Decode(nil, src)
+}
@@ -0,0 +1,57 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestDecodeBounds feeds each decoder crafted streams that end mid-sequence
+// or reference codes outside the table. None may read past len(src) — the
+// clearest symptom would be a panic here, or a SIGSEGV on mmap'd memory.
+func TestDecodeBounds(t *testing.T) {
+	corpus := []byte(strings.Repeat("bounds audit for mapped decoding ", 100))
+	tables := map[string]*Table{
+		"plain":    Train([][]byte{corpus}),
+		"extended": Train([][]byte{corpus}, WithExtendedCodes(true)),
+		"macros":   Train([][]byte{corpus}, WithMacros(true)),
+	}
+	crafted := [][]byte{
+		{},
+		{fsstEscapeCode},             // escape with no literal
+		{0, fsstEscapeCode},          // valid code then truncated escape
+		{fsstExtendedPrefix},         // extended prefix with no low byte
+		{254, 255},                   // prefix then escape, both incomplete
+		{253, 252, 251, 250},         // codes likely beyond nSymbols
+		bytes.Repeat([]byte{255}, 9), // odd-length run of escapes
+	}
+	for name, tbl := range tables {
+		for _, src := range crafted {
+			_ = tbl.DecodeMmap(src)
+		}
+		// A valid stream still roundtrips through DecodeMmap.
+		comp := tbl.Encode(nil, corpus)
+		if got := tbl.DecodeMmap(comp); !bytes.Equal(got, corpus) {
+			t.Fatalf("%s: DecodeMmap roundtrip failed", name)
+		}
+	}
+}
+
+func FuzzDecodeMmap(f *testing.F) {
+	corpus := []byte(strings.Repeat("fuzzing mapped decode safety ", 50))
+	tables := []*Table{
+		Train([][]byte{corpus}),
+		Train([][]byte{corpus}, WithExtendedCodes(true)),
+		Train([][]byte{corpus}, WithMacros(true)),
+	}
+	f.Add(tables[0].Encode(nil, corpus))
+	f.Add([]byte{fsstEscapeCode})
+	f.Add([]byte{254, 253, 255})
+	f.Fuzz(func(t *testing.T, src []byte) {
+		// Arbitrary streams must decode without panicking or reading past
+		// src, against every table mode.
+		for _, tbl := range tables {
+			_ = tbl.DecodeMmap(src)
+		}
+	})
+}
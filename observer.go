@@ -0,0 +1,32 @@
+package fsst
+
+// Observer receives callbacks at key points of training and encoding, for
+// wiring progress and efficiency numbers into an external metrics system
+// without forking the package. Implementations must be cheap: callbacks run
+// inline on the training and encode paths. When no observer is set, each
+// hook site costs a single nil check.
+type Observer interface {
+	// OnRound is called after each training iteration with the sampling
+	// fraction used that round (8..128) and the number of symbols selected
+	// so far.
+	OnRound(frac int, nSymbols int)
+
+	// OnEncodeChunk is called after each chunk of the plain Encode path with
+	// the input bytes consumed and output bytes produced, including the
+	// sub-8-byte tail chunk. Macro rewriting and specialized paths (ordered,
+	// backref, streaming) do not report.
+	OnEncodeChunk(in, out int)
+}
+
+// WithObserver attaches an observer to training: OnRound fires after every
+// iteration, and the trained table keeps the observer so its Encode calls
+// report chunks. Pass nil to train unobserved (the default).
+func WithObserver(o Observer) TrainOption {
+	return func(cfg *trainConfig) { cfg.observer = o }
+}
+
+// SetObserver attaches an observer to the table's Encode path, replacing any
+// previous one; nil detaches. Must not be called concurrently with Encode.
+func (t *Table) SetObserver(o Observer) {
+	t.observer = o
+}
@@ -0,0 +1,65 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type recordingObserver struct {
+	fracs    []int
+	nSymbols []int
+	ins      []int
+	outs     []int
+}
+
+func (r *recordingObserver) OnRound(frac, nSymbols int) {
+	r.fracs = append(r.fracs, frac)
+	r.nSymbols = append(r.nSymbols, nSymbols)
+}
+
+func (r *recordingObserver) OnEncodeChunk(in, out int) {
+	r.ins = append(r.ins, in)
+	r.outs = append(r.outs, out)
+}
+
+func TestObserver(t *testing.T) {
+	obs := &recordingObserver{}
+	corpus := [][]byte{[]byte(strings.Repeat("observed training corpus line ", 200))}
+	tbl := Train(corpus, WithObserver(obs))
+
+	// One OnRound per training iteration, with the fixed frac schedule.
+	wantFracs := []int{8, 38, 68, 98, 128}
+	if len(obs.fracs) != len(wantFracs) {
+		t.Fatalf("OnRound fired %d times, want %d", len(obs.fracs), len(wantFracs))
+	}
+	for i, frac := range wantFracs {
+		if obs.fracs[i] != frac {
+			t.Fatalf("round %d: frac = %d, want %d", i, obs.fracs[i], frac)
+		}
+	}
+	if last := obs.nSymbols[len(obs.nSymbols)-1]; last != tbl.NumSymbols() {
+		t.Fatalf("final OnRound nSymbols = %d, table has %d", last, tbl.NumSymbols())
+	}
+
+	// The trained table carries the observer: chunk totals must match the
+	// actual input and output sizes exactly, tail included.
+	input := bytes.Repeat(corpus[0], 3) // several chunks plus a short tail
+	comp := tbl.Encode(nil, input)
+	inTotal, outTotal := 0, 0
+	for i := range obs.ins {
+		inTotal += obs.ins[i]
+		outTotal += obs.outs[i]
+	}
+	if inTotal != len(input) || outTotal != len(comp) {
+		t.Fatalf("chunk totals = (%d, %d), want (%d, %d)", inTotal, outTotal, len(input), len(comp))
+	}
+
+	// Detaching stops the callbacks.
+	tbl.SetObserver(nil)
+	before := len(obs.ins)
+	tbl.Encode(nil, input)
+	if len(obs.ins) != before {
+		t.Fatal("OnEncodeChunk fired after SetObserver(nil)")
+	}
+}
@@ -0,0 +1,385 @@
+package fsst
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+)
+
+// Order-preserving mode. Greedy longest-match compression cannot preserve
+// lexicographic order when one symbol is a prefix of another (code("ab") would
+// have to sort both below and above code("a")), so this mode restricts the
+// table to a prefix-free symbol set arranged in a trie. Codes are assigned by
+// an in-order walk: every kept symbol becomes a leaf code, and every internal
+// trie node gets one "gap" code per range between its child edges, emitted
+// when the input diverges below the node. A gap code expands to the node's
+// prefix and sorts exactly where inputs in its byte range must sort. Byte
+// values >= ordEscMin are emitted as escape sequences; the escape code (255)
+// is above every assigned code, so escaped bytes sort last, matching their
+// position in byte order. The decode side is a plain FSST code stream — gap
+// codes are ordinary symbols whose expansion happens to be a prefix — so
+// Decode and every stream walker work unchanged.
+
+// ordNode is one node of the order-preserving encode trie. A leaf carries the
+// code of a kept symbol (or of a plain single byte); an internal node carries
+// its sorted child edges plus one gap code per range between them.
+type ordNode struct {
+	depth      int  // bytes matched to reach this node (= expansion length)
+	leaf       bool
+	code       uint8      // assigned code (leaves only)
+	childBytes []byte     // sorted edge labels (internal nodes only)
+	children   []*ordNode // parallel to childBytes
+	gapCodes   []uint8    // len(childBytes)+1 range codes (internal nodes only)
+}
+
+// TrainOrderPreserving learns a table whose compressed outputs sort in the
+// same order as their plaintexts, so bytes.Compare on encoded values matches
+// the plaintext order — useful for compressed sort keys and range-scannable
+// indexes. It trains a regular table first, then keeps the highest-gain
+// symbols that fit the prefix-free trie within the 255-code budget, so the
+// compression ratio is lower than Train's on the same data.
+//
+// The ordering guarantee covers Encode and its variants (AppendEncode,
+// EncodeAppendAt, EncodeMany, FrozenTable.Encode). EncodeStream falls back to
+// standard matching: its output still decodes correctly but does not preserve
+// order. Ordered tables serialize under their own format flag and reject the
+// compressed, proto, and packed representations.
+func TrainOrderPreserving(inputs [][]byte) *Table {
+	base := Train(inputs)
+	sample := makeSample(inputs)
+	var flat []byte
+	for i := range sample {
+		flat = append(flat, sample[i]...)
+	}
+
+	// Escape the top of the byte space: byte 255 always (256 single-byte
+	// codes cannot fit the 255-code budget), extended down through values the
+	// sample never contains. Escaping costs two output bytes, so only
+	// unseen values are traded away for symbol slots.
+	var freq [256]int
+	for _, b := range flat {
+		freq[b]++
+	}
+	escMin := 255
+	for escMin > 1 && freq[escMin-1] == 0 {
+		escMin--
+	}
+
+	// Score the base table's multi-byte symbols by bytes saved on the sample.
+	type ordCand struct {
+		sym  []byte
+		gain int
+	}
+	var cands []ordCand
+	for i := range int(base.nSymbols) {
+		sym := base.SymbolOf(i)
+		if len(sym) < 2 || int(sym[0]) >= escMin {
+			continue
+		}
+		if gain := bytes.Count(flat, sym) * (len(sym) - 1); gain > 0 {
+			cands = append(cands, ordCand{sym, gain})
+		}
+	}
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].gain != cands[j].gain {
+			return cands[i].gain > cands[j].gain
+		}
+		return bytes.Compare(cands[i].sym, cands[j].sym) < 0
+	})
+
+	// Keep candidates greedily while they stay prefix-free against the kept
+	// set and the trie (leaves plus gap codes plus remaining single-byte
+	// codes) still fits the code budget.
+	var kept [][]byte
+	for _, c := range cands {
+		conflict := false
+		for _, k := range kept {
+			if bytes.HasPrefix(k, c.sym) || bytes.HasPrefix(c.sym, k) {
+				conflict = true
+				break
+			}
+		}
+		if conflict {
+			continue
+		}
+		trial := append(kept, c.sym)
+		if _, nCodes, err := buildOrdTrie(trial, escMin); err != nil || nCodes > fsstMaxSymbols {
+			continue
+		}
+		kept = trial
+	}
+	sort.Slice(kept, func(i, j int) bool { return bytes.Compare(kept[i], kept[j]) < 0 })
+
+	t := newTable()
+	if err := t.buildOrdered(kept, escMin); err != nil {
+		// cannot happen: every addition was validated by the greedy loop
+		panic("fsst: internal error building order-preserving table")
+	}
+	return t
+}
+
+// buildOrdTrie builds the encode trie over the kept symbols and reports how
+// many codes it needs: one per leaf (kept symbols and uncovered single
+// bytes) plus one per gap range of each internal node. Symbols must be 2-8
+// bytes, start below escMin, and be prefix-free; violations return
+// ErrBadFormat so deserialization can reuse the same validation.
+func buildOrdTrie(leaves [][]byte, escMin int) (*[256]*ordNode, int, error) {
+	if escMin < 1 || escMin > 255 {
+		return nil, 0, ErrBadFormat
+	}
+	root := new([256]*ordNode)
+	for _, sym := range leaves {
+		if len(sym) < 2 || len(sym) > 8 || int(sym[0]) >= escMin {
+			return nil, 0, ErrBadFormat
+		}
+		n := root[sym[0]]
+		if n == nil {
+			n = &ordNode{depth: 1}
+			root[sym[0]] = n
+		}
+		if err := ordInsert(n, sym); err != nil {
+			return nil, 0, err
+		}
+	}
+	nCodes := 0
+	for b := 0; b < escMin; b++ {
+		if root[b] == nil {
+			root[b] = &ordNode{depth: 1, leaf: true}
+		}
+		nCodes += ordCountCodes(root[b])
+	}
+	return root, nCodes, nil
+}
+
+// ordInsert threads sym into the trie below internal node n, which has
+// already matched sym[:n.depth]. A symbol ending at an existing node or
+// passing through a leaf would make one kept symbol a prefix of another.
+func ordInsert(n *ordNode, sym []byte) error {
+	b := sym[n.depth]
+	idx := 0
+	for idx < len(n.childBytes) && n.childBytes[idx] < b {
+		idx++
+	}
+	if idx < len(n.childBytes) && n.childBytes[idx] == b {
+		child := n.children[idx]
+		if child.leaf || child.depth == len(sym) {
+			return ErrBadFormat
+		}
+		return ordInsert(child, sym)
+	}
+	var child *ordNode
+	if n.depth+1 == len(sym) {
+		child = &ordNode{depth: len(sym), leaf: true}
+	} else {
+		child = &ordNode{depth: n.depth + 1}
+		if err := ordInsert(child, sym); err != nil {
+			return err
+		}
+	}
+	n.childBytes = append(n.childBytes, 0)
+	copy(n.childBytes[idx+1:], n.childBytes[idx:])
+	n.childBytes[idx] = b
+	n.children = append(n.children, nil)
+	copy(n.children[idx+1:], n.children[idx:])
+	n.children[idx] = child
+	return nil
+}
+
+// ordCountCodes returns the number of codes the subtree under n consumes.
+func ordCountCodes(n *ordNode) int {
+	if n.leaf {
+		return 1
+	}
+	c := len(n.childBytes) + 1
+	for _, child := range n.children {
+		c += ordCountCodes(child)
+	}
+	return c
+}
+
+// buildOrdered constructs the full ordered-mode table state from the kept
+// symbols and escape bound: the encode trie, the in-order code assignment,
+// and the canonical code->expansion mapping the standard decoder reads.
+// Training and deserialization both land here, so a reloaded table assigns
+// byte-identical codes.
+func (t *Table) buildOrdered(leaves [][]byte, escMin int) error {
+	root, nCodes, err := buildOrdTrie(leaves, escMin)
+	if err != nil {
+		return err
+	}
+	if nCodes > fsstMaxSymbols {
+		return ErrBadFormat
+	}
+
+	t.lenHisto = [8]uint16{}
+	code := uint16(0)
+	var prefix [8]byte
+	emit := func(depth int) uint8 {
+		var val uint64
+		for i := 0; i < depth; i++ {
+			val |= uint64(prefix[i]) << (8 * i)
+		}
+		sym := symbol{val: val}
+		sym.setCodeLen(uint32(code), uint32(depth))
+		t.symbols[code] = sym
+		t.lenHisto[depth-1]++
+		c := uint8(code)
+		code++
+		return c
+	}
+	var visit func(n *ordNode)
+	visit = func(n *ordNode) {
+		if n.leaf {
+			n.code = emit(n.depth)
+			return
+		}
+		n.gapCodes = make([]uint8, len(n.childBytes)+1)
+		n.gapCodes[0] = emit(n.depth)
+		for i, child := range n.children {
+			prefix[n.depth] = n.childBytes[i]
+			visit(child)
+			n.gapCodes[i+1] = emit(n.depth)
+		}
+	}
+	for b := 0; b < escMin; b++ {
+		prefix[0] = byte(b)
+		visit(root[b])
+	}
+
+	t.nSymbols = code
+	t.suffixLim = 0
+	t.ordered = true
+	t.ordEscMin = uint8(escMin)
+	t.ordLeaves = leaves
+	t.ordRoot = root
+	t.invalidateCaches()
+	return nil
+}
+
+// writeToOrdered serializes an ordered-mode table: a version word carrying
+// the fsstFormatOrdered flag, the escape bound in the suffixLim slot, and the
+// leaf count in the nSymbols slot, followed by each kept symbol as a length
+// byte plus its bytes. Gap and single-byte codes are not stored — buildOrdered
+// regenerates them deterministically on read.
+func (t *Table) writeToOrdered(w io.Writer) (int64, error) {
+	ver := (fsstVersion << 32) |
+		(uint64(t.ordEscMin) << 16) |
+		(uint64(len(t.ordLeaves)) << 8) |
+		fsstFormatOrdered
+	var (
+		n    int64
+		buf8 [8]byte
+	)
+	binary.LittleEndian.PutUint64(buf8[:], ver)
+	if nn, err := w.Write(buf8[:]); err != nil {
+		return n, err
+	} else {
+		n += int64(nn)
+	}
+	for _, leaf := range t.ordLeaves {
+		buf8[0] = byte(len(leaf))
+		if nn, err := w.Write(buf8[:1]); err != nil {
+			return n, err
+		} else {
+			n += int64(nn)
+		}
+		if nn, err := w.Write(leaf); err != nil {
+			return n, err
+		} else {
+			n += int64(nn)
+		}
+	}
+	return n, nil
+}
+
+// readFromOrdered finishes deserializing after the version word of a table
+// written by writeToOrdered. The newTable reset already happened in ReadFrom.
+func (t *Table) readFromOrdered(r io.Reader, n int64, ver uint64) (int64, error) {
+	escMin := int((ver >> 16) & fsstMask8)
+	count := int((ver >> 8) & fsstMask8)
+	leaves := make([][]byte, 0, count)
+	var lb [1]byte
+	for range count {
+		if _, err := io.ReadFull(r, lb[:]); err != nil {
+			return n, err
+		}
+		n++
+		length := int(lb[0])
+		if length < 2 || length > 8 {
+			return n, ErrBadFormat
+		}
+		leaf := make([]byte, length)
+		if _, err := io.ReadFull(r, leaf); err != nil {
+			return n, err
+		}
+		n += int64(length)
+		leaves = append(leaves, leaf)
+	}
+	if err := t.buildOrdered(leaves, escMin); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// encodeOrdered is the Encode body for ordered tables: allocate the
+// worst-case output (all escapes) and delegate to the append-at core.
+func (t *Table) encodeOrdered(buf, input []byte) []byte {
+	if cap(buf) < 2*len(input)+fsstOutputPadding {
+		buf = make([]byte, 2*len(input)+fsstOutputPadding)
+	} else {
+		buf = buf[:cap(buf)]
+	}
+	return buf[:t.encodeOrderedAt(buf, 0, input)]
+}
+
+// encodeOrderedAt encodes input into dst starting at pos and returns the
+// position after the last written byte. For each input position it descends
+// the trie as far as the bytes match: a leaf emits the symbol's code, a
+// divergence emits the gap code of the range the mismatching byte falls in
+// (input ending mid-descent uses the lowest gap, keeping prefixes sorted
+// first). It reads only the immutable trie, so concurrent calls never race.
+func (t *Table) encodeOrderedAt(dst []byte, pos int, input []byte) int {
+	escMin := int(t.ordEscMin)
+	root := t.ordRoot
+	for i := 0; i < len(input); {
+		b := input[i]
+		if int(b) >= escMin {
+			dst[pos] = fsstEscapeCode
+			dst[pos+1] = b
+			pos += 2
+			i++
+			continue
+		}
+		n := root[b]
+		for {
+			if n.leaf {
+				dst[pos] = n.code
+				pos++
+				i += n.depth
+				break
+			}
+			j := i + n.depth
+			if j >= len(input) {
+				dst[pos] = n.gapCodes[0]
+				pos++
+				i += n.depth
+				break
+			}
+			x := input[j]
+			idx := 0
+			for idx < len(n.childBytes) && n.childBytes[idx] < x {
+				idx++
+			}
+			if idx < len(n.childBytes) && n.childBytes[idx] == x {
+				n = n.children[idx]
+				continue
+			}
+			dst[pos] = n.gapCodes[idx]
+			pos++
+			i += n.depth
+			break
+		}
+	}
+	return pos
+}
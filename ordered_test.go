@@ -0,0 +1,131 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func orderedTestCorpus() [][]byte {
+	var corpus [][]byte
+	for i := 0; i < 200; i++ {
+		corpus = append(corpus, []byte(fmt.Sprintf("user=%04d action=login status=ok region=eu-west-1", i)))
+	}
+	return corpus
+}
+
+func TestOrderPreservingCompare(t *testing.T) {
+	tbl := TrainOrderPreserving(orderedTestCorpus())
+
+	// Values chosen to stress every divergence shape: shared prefixes of
+	// different lengths, one string a prefix of another, divergence inside
+	// and outside learned symbols, and bytes the table escapes.
+	values := [][]byte{
+		{},
+		[]byte("a"),
+		[]byte("action"),
+		[]byte("action="),
+		[]byte("action=login"),
+		[]byte("action=logout"),
+		[]byte("action=zzz"),
+		[]byte("status=ok"),
+		[]byte("status=ok region=eu-west-1"),
+		[]byte("status=okay"),
+		[]byte("user=0001 action=login status=ok region=eu-west-1"),
+		[]byte("user=0001 action=login status=ok region=eu-west-2"),
+		[]byte("user=0002"),
+		[]byte("user=9999 action=login"),
+		[]byte("zeta"),
+		{0x00, 0x01},
+		{0x7f, 0x80, 0xff},
+		{0xfe},
+		{0xff, 0x00},
+		{0xff, 0xff},
+	}
+	encoded := make([][]byte, len(values))
+	for i, v := range values {
+		encoded[i] = tbl.Encode(nil, v)
+		if got := tbl.Decode(nil, encoded[i]); !bytes.Equal(got, v) {
+			t.Fatalf("roundtrip mismatch for %q: got %q", v, got)
+		}
+	}
+	for i := range values {
+		for j := range values {
+			want := bytes.Compare(values[i], values[j])
+			if got := bytes.Compare(encoded[i], encoded[j]); got != want {
+				t.Fatalf("order not preserved: Compare(%q, %q) = %d on encoded form, want %d",
+					values[i], values[j], got, want)
+			}
+		}
+	}
+}
+
+func TestOrderPreservingCompresses(t *testing.T) {
+	corpus := orderedTestCorpus()
+	tbl := TrainOrderPreserving(corpus)
+	var raw, enc int
+	for _, rec := range corpus {
+		raw += len(rec)
+		enc += len(tbl.Encode(nil, rec))
+	}
+	if enc >= raw {
+		t.Fatalf("ordered table does not compress: %d encoded vs %d raw bytes", enc, raw)
+	}
+}
+
+func TestOrderPreservingSerialization(t *testing.T) {
+	tbl := TrainOrderPreserving(orderedTestCorpus())
+	var buf bytes.Buffer
+	n, err := tbl.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if int(n) != tbl.SerializedLen() || buf.Len() != int(n) {
+		t.Fatalf("wrote %d bytes, SerializedLen says %d", n, tbl.SerializedLen())
+	}
+
+	var loaded Table
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if !loaded.Equal(tbl) {
+		t.Fatal("reloaded table differs from original")
+	}
+	if loaded.Fingerprint() != tbl.Fingerprint() {
+		t.Fatal("reloaded table fingerprint differs")
+	}
+	input := []byte("user=0042 action=login status=ok")
+	if !bytes.Equal(loaded.Encode(nil, input), tbl.Encode(nil, input)) {
+		t.Fatal("reloaded table encodes differently")
+	}
+}
+
+func TestOrderPreservingEncoderViews(t *testing.T) {
+	tbl := TrainOrderPreserving(orderedTestCorpus())
+	input := []byte("user=0042 action=login status=ok region=eu-west-1")
+	want := tbl.Encode(nil, input)
+
+	if got := tbl.Encoder().Encode(nil, input); !bytes.Equal(got, want) {
+		t.Fatalf("CompactEncoder output differs: %q vs %q", got, want)
+	}
+	frozen := FreezeTable(tbl.Clone())
+	if got := frozen.Encode(nil, input); !bytes.Equal(got, want) {
+		t.Fatalf("FrozenTable output differs: %q vs %q", got, want)
+	}
+	if got := frozen.Decode(nil, want); !bytes.Equal(got, input) {
+		t.Fatalf("FrozenTable decode = %q, want %q", got, input)
+	}
+}
+
+func TestOrderPreservingRejectsOtherFormats(t *testing.T) {
+	tbl := TrainOrderPreserving(orderedTestCorpus())
+	if _, err := tbl.WriteToCompressed(&bytes.Buffer{}); err != ErrBadFormat {
+		t.Fatalf("WriteToCompressed err = %v, want ErrBadFormat", err)
+	}
+	if tbl.ToProto() != nil {
+		t.Fatal("ToProto should reject ordered tables")
+	}
+	if tbl.EncodePacked(nil, []byte("x")) != nil {
+		t.Fatal("EncodePacked should reject ordered tables")
+	}
+}
@@ -0,0 +1,32 @@
+package fsst
+
+// fsstWorthwhileRatio is the default TrainOrNil cutoff: a table whose
+// estimated ratio on the training sample is above this is judged not worth
+// the table storage and decode indirection.
+const fsstWorthwhileRatio = 0.95
+
+// TrainOrNil trains a table and returns it only if it is worth using: when
+// the estimated compression ratio on the training sample is above 0.95
+// (less than 5% saved), it returns nil so a storage layer can fall back to
+// raw bytes without measuring the ratio itself. Incompressible data — already
+// compressed blobs, ciphertext, random identifiers — lands well above the
+// cutoff; structured text lands well below it. Use TrainOrNilRatio to pick a
+// different cutoff.
+func TrainOrNil(inputs [][]byte) *Table {
+	return TrainOrNilRatio(inputs, fsstWorthwhileRatio)
+}
+
+// TrainOrNilRatio is TrainOrNil with an explicit cutoff: the trained table is
+// returned only while its estimated ratio (compressed size / original size on
+// the training sample) does not exceed maxRatio. A stricter cutoff like 0.5
+// keeps FSST only where it at least halves the data; maxRatio >= 1 accepts
+// any table that does not expand the sample. Empty inputs return nil.
+func TrainOrNilRatio(inputs [][]byte, maxRatio float64) *Table {
+	t := Train(inputs)
+	sample := makeSample(inputs)
+	ratio := t.EstimateRatio(sample)
+	if ratio == 0 || ratio > maxRatio {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,38 @@
+package fsst
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrainOrNil(t *testing.T) {
+	structured := [][]byte{[]byte(strings.Repeat("level=info msg=\"request served\" status=200 ", 200))}
+	if TrainOrNil(structured) == nil {
+		t.Fatal("TrainOrNil returned nil for highly compressible data")
+	}
+
+	// Pseudo-random bytes via the training hash chain: incompressible, so
+	// the estimated ratio sits near 1 and the table is not worth keeping.
+	random := make([]byte, 32<<10)
+	rng := fsstHash(rngSeed)
+	for i := range random {
+		rng = fsstHash(rng)
+		random[i] = byte(rng)
+	}
+	if TrainOrNil([][]byte{random}) != nil {
+		t.Fatal("TrainOrNil returned a table for incompressible data")
+	}
+
+	if TrainOrNil(nil) != nil {
+		t.Fatal("TrainOrNil returned a table for empty inputs")
+	}
+
+	// An over-strict cutoff rejects even compressible data; a permissive one
+	// accepts the near-1 ratio on random bytes.
+	if TrainOrNilRatio(structured, 0.01) != nil {
+		t.Fatal("cutoff 0.01 should reject everything realistic")
+	}
+	if TrainOrNilRatio([][]byte{random}, 1.5) == nil {
+		t.Fatal("cutoff 1.5 should accept a non-expanding table")
+	}
+}
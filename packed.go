@@ -0,0 +1,130 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// ErrBadPacked is returned by DecodePacked when src is not a valid packed
+// code stream for this table.
+var ErrBadPacked = errors.New("fsst: invalid packed code stream")
+
+// packedWidth returns the number of bits per packed code: enough for codes
+// 0..nSymbols-1 plus the escape marker, which packing remaps from 255 down
+// to nSymbols so it fits the narrow width.
+func (t *Table) packedWidth() uint {
+	return uint(max(bits.Len(uint(t.nSymbols)), 1))
+}
+
+// EncodePacked compresses input like Encode but bit-packs each code at
+// ceil(log2(nSymbols+1)) bits instead of a full byte, improving the ratio on
+// tables with small vocabularies (a 60-symbol table spends 6 bits per code
+// instead of 8). Escaped literals still cost 8 bits after the escape marker.
+//
+// The output is a distinct wire format: one header byte holding the code
+// width in the low nibble and the trailing padding bit count in the high
+// nibble, followed by the packed bits. Only DecodePacked can read it, and
+// decoding is slower than Decode because codes no longer fall on byte
+// boundaries. Returns nil for tables using extended codes, macros, backrefs,
+// or order-preserving codes. buf can be nil or undersized; it will be grown
+// as needed.
+func (t *Table) EncodePacked(buf, input []byte) []byte {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 || t.ordered {
+		return nil
+	}
+	t.frameBuf = t.Encode(t.frameBuf, input)
+
+	w := t.packedWidth()
+	need := 1 + len(t.frameBuf) + 1 // packed codes never exceed their byte form
+	if cap(buf) < need {
+		buf = make([]byte, 0, need)
+	}
+	buf = buf[:0]
+	buf = append(buf, byte(w))
+
+	var acc uint64
+	var nbits uint
+	emit := func(v uint32, n uint) {
+		acc |= uint64(v) << nbits
+		nbits += n
+		for nbits >= 8 {
+			buf = append(buf, byte(acc))
+			acc >>= 8
+			nbits -= 8
+		}
+	}
+	escape := uint32(t.nSymbols)
+	for i := 0; i < len(t.frameBuf); i++ {
+		if code := t.frameBuf[i]; code == fsstEscapeCode {
+			emit(escape, w)
+			i++
+			emit(uint32(t.frameBuf[i]), 8)
+		} else {
+			emit(uint32(code), w)
+		}
+	}
+	if nbits > 0 {
+		buf = append(buf, byte(acc))
+		buf[0] |= byte(8-nbits) << 4 // record the zero padding in the last byte
+	}
+	return buf
+}
+
+// DecodePacked decompresses a stream produced by EncodePacked. It returns
+// ErrBadPacked when src is empty, the header's code width does not match
+// this table, or the bit stream is truncated or references codes beyond the
+// symbol count. buf follows the same reuse semantics as Decode.
+func (t *Table) DecodePacked(buf, src []byte) ([]byte, error) {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 || t.ordered {
+		return nil, ErrBadPacked
+	}
+	w := t.packedWidth()
+	if len(src) == 0 || uint(src[0]&0x0f) != w || src[0]>>4 > 7 {
+		return nil, ErrBadPacked
+	}
+	t.ensureDecoder()
+
+	if buf == nil {
+		buf = make([]byte, 0, len(src)*4+8)
+	} else {
+		buf = buf[:0]
+	}
+
+	bitsLeft := (len(src)-1)*8 - int(src[0]>>4)
+	var acc uint64
+	var nbits uint
+	pos := 1
+	read := func(n uint) uint32 {
+		for nbits < n {
+			acc |= uint64(src[pos]) << nbits
+			pos++
+			nbits += 8
+		}
+		v := uint32(acc) & (1<<n - 1)
+		acc >>= n
+		nbits -= n
+		return v
+	}
+
+	escape := uint32(t.nSymbols)
+	var sym [8]byte
+	for bitsLeft >= int(w) {
+		code := read(w)
+		bitsLeft -= int(w)
+		switch {
+		case code < escape:
+			binary.LittleEndian.PutUint64(sym[:], t.decSymbol[code])
+			buf = append(buf, sym[:t.decLen[code]]...)
+		case code == escape:
+			if bitsLeft < 8 {
+				return nil, ErrBadPacked
+			}
+			buf = append(buf, byte(read(8)))
+			bitsLeft -= 8
+		default:
+			return nil, ErrBadPacked
+		}
+	}
+	return buf, nil
+}
@@ -0,0 +1,66 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodePackedRoundtrip(t *testing.T) {
+	// An enum-like column keeps the vocabulary far below 256 symbols, which
+	// is exactly where bit-packing pays off.
+	input := []byte(strings.Repeat("red,green,blue,amber,violet\n", 400))
+	tbl := Train([][]byte{input})
+
+	packed := tbl.EncodePacked(nil, input)
+	got, err := tbl.DecodePacked(nil, packed)
+	if err != nil {
+		t.Fatalf("DecodePacked: %v", err)
+	}
+	if !bytes.Equal(got, input) {
+		t.Fatalf("packed roundtrip mismatch")
+	}
+
+	plain := tbl.Encode(nil, input)
+	if len(packed) >= len(plain) {
+		t.Fatalf("packed %d bytes not smaller than plain %d on a %d-symbol table",
+			len(packed), len(plain), tbl.nSymbols)
+	}
+
+	// Inputs with bytes outside the vocabulary still roundtrip via escapes.
+	foreign := []byte("red,GREEN,\x00\xff,blue\n")
+	packed = tbl.EncodePacked(packed[:0], foreign)
+	if got, err = tbl.DecodePacked(got[:0], packed); err != nil || !bytes.Equal(got, foreign) {
+		t.Fatalf("escape roundtrip failed: %v", err)
+	}
+
+	// Empty input produces just the header and decodes to empty.
+	packed = tbl.EncodePacked(nil, nil)
+	if got, err = tbl.DecodePacked(nil, packed); err != nil || len(got) != 0 {
+		t.Fatalf("empty roundtrip failed: %v", err)
+	}
+}
+
+func TestDecodePackedRejectsBadFormat(t *testing.T) {
+	input := []byte(strings.Repeat("red,green,blue\n", 200))
+	tbl := Train([][]byte{input})
+	packed := tbl.EncodePacked(nil, input)
+
+	if _, err := tbl.DecodePacked(nil, nil); err != ErrBadPacked {
+		t.Fatalf("empty src: got %v, want ErrBadPacked", err)
+	}
+
+	// A header advertising the wrong code width cannot be for this table.
+	bad := append([]byte(nil), packed...)
+	bad[0] ^= 0x0f
+	if _, err := tbl.DecodePacked(nil, bad); err != ErrBadPacked {
+		t.Fatalf("wrong width: got %v, want ErrBadPacked", err)
+	}
+
+	// Plain Encode output is not a packed stream; the header byte gives it
+	// away unless it happens to collide with the expected width.
+	plain := tbl.Encode(nil, input)
+	if _, err := tbl.DecodePacked(nil, plain); err == nil && uint(plain[0]&0x0f) != tbl.packedWidth() {
+		t.Fatalf("plain stream accepted as packed")
+	}
+}
@@ -0,0 +1,55 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestWithPairMergingDisabled(t *testing.T) {
+	data, err := os.ReadFile("testdata/en_mobydick.txt")
+	if err != nil {
+		t.Skip("corpus not available")
+	}
+	data = data[:1<<17]
+	inputs := [][]byte{data}
+
+	fast := Train(inputs, WithPairMerging(false))
+
+	// Without merging nothing longer than one byte can form.
+	for i := 1; i < len(fast.lenHisto); i++ {
+		if fast.lenHisto[i] != 0 {
+			t.Fatalf("pair merging disabled but %d symbols of length %d learned", fast.lenHisto[i], i+1)
+		}
+	}
+
+	// Roundtrip still holds, it just compresses worse than the full table.
+	comp := fast.Encode(nil, data[:4096])
+	if got := fast.Decode(nil, comp); !bytes.Equal(got, data[:4096]) {
+		t.Fatalf("roundtrip failed without pair merging")
+	}
+	full := Train(inputs)
+	holdout := makeSample(inputs)
+	if f, p := fast.EstimateRatio(holdout), full.EstimateRatio(holdout); f < p {
+		t.Fatalf("single-byte table ratio %f beats full table %f", f, p)
+	}
+}
+
+func BenchmarkTrainPairMerging(b *testing.B) {
+	data, err := os.ReadFile("testdata/en_mobydick.txt")
+	if err != nil {
+		b.Skip("corpus not available")
+	}
+	inputs := [][]byte{data}
+
+	b.Run("merged", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Train(inputs)
+		}
+	})
+	b.Run("singles", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Train(inputs, WithPairMerging(false))
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package fsst
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DecodeParallel decodes independent compressed blocks concurrently and
+// returns the results in block order. FSST carries no state across blocks, so
+// a partitioned column (or any blob split at known block boundaries) can be
+// decompressed on all cores at once. workers caps the goroutine count;
+// workers <= 0 uses GOMAXPROCS, and the count never exceeds the number of
+// blocks. Each block's output is freshly allocated, so workers never share
+// buffers. The decoder arrays are warmed once up front, which makes the
+// concurrent Decode calls safe per the Table concurrency contract.
+func (t *Table) DecodeParallel(blocks [][]byte, workers int) [][]byte {
+	if len(blocks) == 0 {
+		return nil
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(blocks))
+	out := make([][]byte, len(blocks))
+	if workers == 1 {
+		for i := range blocks {
+			out[i] = t.Decode(nil, blocks[i])
+		}
+		return out
+	}
+
+	t.WarmDecoder()
+	var (
+		next atomic.Int64
+		wg   sync.WaitGroup
+	)
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(blocks) {
+					return
+				}
+				out[i] = t.Decode(nil, blocks[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,51 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestDecodeParallel(t *testing.T) {
+	var records [][]byte
+	for i := 0; i < 100; i++ {
+		records = append(records, []byte(fmt.Sprintf("block %03d: quick brown fox payload with shared words", i)))
+	}
+	tbl := Train(records)
+
+	blocks := make([][]byte, len(records))
+	for i, rec := range records {
+		blocks[i] = tbl.Encode(nil, rec)
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		got := tbl.DecodeParallel(blocks, workers)
+		if len(got) != len(blocks) {
+			t.Fatalf("workers=%d: got %d results, want %d", workers, len(got), len(blocks))
+		}
+		for i := range got {
+			if want := tbl.Decode(nil, blocks[i]); !bytes.Equal(got[i], want) {
+				t.Fatalf("workers=%d block %d: got %q, want %q", workers, i, got[i], want)
+			}
+		}
+	}
+
+	if got := tbl.DecodeParallel(nil, 4); got != nil {
+		t.Fatalf("DecodeParallel(nil) = %v, want nil", got)
+	}
+}
+
+func TestDecodeParallelBackrefs(t *testing.T) {
+	corpus := [][]byte{bytes.Repeat([]byte("repeated sequence for backref matching "), 40)}
+	tbl := Train(corpus, WithBackrefs(128))
+
+	var blocks [][]byte
+	for i := 0; i < 20; i++ {
+		blocks = append(blocks, tbl.Encode(nil, corpus[0]))
+	}
+	for i, got := range tbl.DecodeParallel(blocks, 8) {
+		if !bytes.Equal(got, corpus[0]) {
+			t.Fatalf("block %d: parallel backref decode mismatch", i)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package fsst
+
+import "encoding/binary"
+
+// DecodePrefix decompresses src into buf but stops once maxBytes decoded
+// bytes have been emitted, skipping the rest of the stream. A symbol that
+// would overshoot the limit is written partially, so the result is exactly
+// DecodeAll(src) truncated to maxBytes. Use it to render previews of long
+// compressed fields without paying for the full decode.
+func (t *Table) DecodePrefix(buf, src []byte, maxBytes int) []byte {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	if buf == nil {
+		buf = make([]byte, 0, min(maxBytes, len(src)*4)+8)
+	} else {
+		buf = buf[:0] // Reset length but keep capacity
+	}
+
+	var tmp [8]byte
+	emit := func(val uint64, length int) {
+		if len(buf)+length > maxBytes {
+			length = maxBytes - len(buf)
+		}
+		binary.LittleEndian.PutUint64(tmp[:], val)
+		buf = append(buf, tmp[:length]...)
+	}
+
+	for srcPos := 0; srcPos < len(src) && len(buf) < maxBytes; {
+		code := uint16(src[srcPos])
+		srcPos++
+		switch {
+		case code == fsstEscapeCode:
+			if srcPos >= len(src) {
+				return buf
+			}
+			buf = append(buf, src[srcPos])
+			srcPos++
+		case t.extended:
+			if code == fsstExtendedPrefix {
+				if srcPos >= len(src) {
+					return buf
+				}
+				code = fsstExtendedPrefix + uint16(src[srcPos])
+				srcPos++
+			}
+			if int(code) < len(t.decLenExt) {
+				emit(t.decSymbolExt[code], int(t.decLenExt[code]))
+			}
+		case t.hasMacros && code >= t.macroBase:
+			if idx := code - t.macroBase; int(idx) < len(t.macros) {
+				for _, c := range t.macros[idx] {
+					emit(t.decSymbol[c], int(t.decLen[c]))
+				}
+			}
+		case t.backrefWindow > 0 && code == t.nSymbols:
+			if srcPos+1 >= len(src) {
+				return buf
+			}
+			dist := int(src[srcPos])
+			length := int(src[srcPos+1])
+			srcPos += 2
+			if dist < 1 || dist > len(buf) {
+				continue
+			}
+			// buf holds the exact decode prefix while under the limit, so
+			// copying from it matches the full decode byte for byte.
+			for k := 0; k < length && len(buf) < maxBytes; k++ {
+				buf = append(buf, buf[len(buf)-dist])
+			}
+		default:
+			emit(t.decSymbol[code], int(t.decLen[code]))
+		}
+	}
+	return buf
+}
@@ -0,0 +1,49 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodePrefix(t *testing.T) {
+	input := []byte(strings.Repeat("preview the first bytes of a long field ", 50))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+	full := tbl.DecodeAll(compressed)
+
+	for _, n := range []int{0, 1, 7, 80, len(input), len(input) + 100} {
+		got := tbl.DecodePrefix(nil, compressed, n)
+		want := full[:min(n, len(full))]
+		if !bytes.Equal(got, want) {
+			t.Fatalf("DecodePrefix(%d) = %d bytes, diverges from DecodeAll prefix", n, len(got))
+		}
+	}
+}
+
+func TestDecodePrefixPartialSymbol(t *testing.T) {
+	// Force long symbols so a limit mid-symbol exercises the partial write.
+	input := []byte(strings.Repeat("abcdefgh", 100))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+	full := tbl.DecodeAll(compressed)
+
+	for n := 1; n < 20; n++ {
+		if got := tbl.DecodePrefix(nil, compressed, n); !bytes.Equal(got, full[:n]) {
+			t.Fatalf("DecodePrefix(%d) = %q, want %q", n, got, full[:n])
+		}
+	}
+}
+
+func TestDecodePrefixMacros(t *testing.T) {
+	inputs := macroCorpus()
+	tbl := Train(inputs, WithMacros(true))
+	compressed := tbl.Encode(nil, inputs[0])
+	full := tbl.Decode(nil, compressed)
+
+	for _, n := range []int{3, 10, len(full)} {
+		if got := tbl.DecodePrefix(nil, compressed, n); !bytes.Equal(got, full[:n]) {
+			t.Fatalf("macro DecodePrefix(%d) mismatch", n)
+		}
+	}
+}
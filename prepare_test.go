@@ -0,0 +1,38 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrepareAvoidsFirstCallAllocations(t *testing.T) {
+	input := []byte(strings.Repeat("latency sensitive startup path ", 40))
+	tbl := Train([][]byte{input})
+	tbl.Prepare()
+
+	if tbl.encBuf == nil {
+		t.Fatalf("Prepare did not allocate encBuf")
+	}
+	if !tbl.decReady {
+		t.Fatalf("Prepare did not build decoder arrays")
+	}
+
+	encBuf := make([]byte, 2*len(input)+fsstOutputPadding)
+	if allocs := testing.AllocsPerRun(10, func() {
+		tbl.Encode(encBuf, input)
+	}); allocs != 0 {
+		t.Fatalf("Encode after Prepare allocated %v times, want 0", allocs)
+	}
+
+	comp := tbl.Encode(encBuf, input)
+	decBuf := make([]byte, len(input))
+	if allocs := testing.AllocsPerRun(10, func() {
+		tbl.Decode(decBuf, comp)
+	}); allocs != 0 {
+		t.Fatalf("Decode after Prepare allocated %v times, want 0", allocs)
+	}
+	if got := tbl.Decode(decBuf, comp); !bytes.Equal(got, input) {
+		t.Fatalf("roundtrip mismatch after Prepare")
+	}
+}
@@ -0,0 +1,103 @@
+package fsst
+
+// EncodeProfile counts how often each encode lookup tier matched while
+// walking an input, mirroring encodeChunkBranched's branches. Comparing
+// TwoByteFast against ShortCode shows whether the unique-prefix fast path
+// (noSuffixOpt) would pay off on a workload, and a large Escape count means
+// the table no longer fits the data. Counts are per emitted code, not per
+// input byte.
+type EncodeProfile struct {
+	TwoByteFast int // 2-byte match with a unique prefix (below suffixLim)
+	ShortCode   int // 2-byte match with a conflicting prefix
+	HashHit     int // 3-8 byte match from the hash tables
+	SingleByte  int // learned 1-byte code
+	Escape      int // escape sequence for an uncovered byte
+}
+
+// EncodeProfile classifies every code the encoder would emit for input. It
+// takes the same decisions as Encode (branched variant) but only counts, so
+// it is for offline tuning — validating chooseVariant's heuristics against
+// real corpora — not for the hot path.
+func (t *Table) EncodeProfile(input []byte) EncodeProfile {
+	t.ensureEncoder()
+
+	var p EncodeProfile
+	inputLen := len(input)
+	chunkLen := t.chunkLen()
+	// Mirror Encode's chunk walk, including the limit that lets matches run
+	// past the soft chunk end, so the counts line up with real encode output.
+	position := 0
+	for position+8 <= inputLen {
+		end := min(position+chunkLen, inputLen-7) - position
+		position += t.profileChunk(&p, input[position:], end, end+7)
+	}
+	if position < inputLen {
+		chunkBuf := t.encBuf
+		tailLen := inputLen - position
+		copy(chunkBuf[:tailLen], input[position:])
+		clear(chunkBuf[tailLen : tailLen+8])
+		t.profileChunk(&p, chunkBuf, tailLen, tailLen)
+	}
+	return p
+}
+
+// profileChunk is encodeChunkBranched with counters instead of output
+// writes; it returns the bytes consumed so the caller can chunk like Encode.
+func (t *Table) profileChunk(p *EncodeProfile, buf []byte, end, limit int) int {
+	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+	suffixLim := uint8(t.suffixLim)
+	position := 0
+
+	for position < end {
+		word := fsstUnalignedLoad(buf[position:])
+		code := t.shortCodes[uint16(word&fsstMask16)]
+		codeU8 := uint8(code)
+
+		if codeU8 < byteLim && position+2 <= limit {
+			if codeU8 < suffixLim {
+				p.TwoByteFast++
+			} else {
+				p.ShortCode++
+			}
+			position += 2
+			continue
+		}
+
+		prefix24 := word & fsstMask24
+		hashIndex := fsstHash(prefix24) & (fsstHashTabSize - 1)
+		var hashSymbol symbol
+		var found bool
+		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFFFFFFFF) && position+7 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFFFFFF) && position+6 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFFFF) && position+5 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFF) && position+4 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFF) && position+3 <= limit {
+			found = true
+		}
+
+		if found {
+			p.HashHit++
+			position += int(hashSymbol.length())
+			continue
+		}
+
+		escapeByte := uint8(word)
+		advance := int(code >> fsstLenBits)
+		if position+advance > limit {
+			code = t.byteCodes[escapeByte]
+		}
+		if (code & fsstCodeBase) != 0 {
+			p.Escape++
+		} else {
+			p.SingleByte++
+		}
+		position++
+	}
+	return position
+}
@@ -0,0 +1,37 @@
+package fsst
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncodeProfile(t *testing.T) {
+	corpus := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	tbl := Train([][]byte{corpus})
+
+	p := tbl.EncodeProfile(corpus)
+	total := p.TwoByteFast + p.ShortCode + p.HashHit + p.SingleByte + p.Escape
+	if total == 0 {
+		t.Fatalf("profile counted no codes")
+	}
+	if p.HashHit == 0 {
+		t.Fatalf("structured corpus produced no 3+ byte matches")
+	}
+	if p.Escape > total/100 {
+		t.Fatalf("training data escaped %d of %d codes", p.Escape, total)
+	}
+
+	// The profile mirrors the branched variant; pin Encode to it so the code
+	// count matches the emitted output (one byte per code, two per escape).
+	tbl.SetVariant(false, false)
+	comp := tbl.Encode(nil, corpus)
+	if got := len(comp) - p.Escape; got != total {
+		t.Fatalf("profile counted %d codes, encoded output holds %d", total, got)
+	}
+
+	// Uncovered bytes show up as escapes.
+	p = tbl.EncodeProfile([]byte("\xff\xfe"))
+	if p.Escape != 2 {
+		t.Fatalf("expected 2 escapes, profile shows %d", p.Escape)
+	}
+}
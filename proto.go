@@ -0,0 +1,63 @@
+package fsst
+
+// TableProto is a plain-struct mirror of a serialized table, for shipping
+// tables inside a proto (or JSON, or any struct-aware) message instead of an
+// opaque bytes field. Fields map one-to-one onto a proto message definition;
+// no generated code or proto dependency is used here. Symbols are in code
+// order, so code i decodes to Symbols[i]. Extended-mode and macro tables
+// don't fit this layout and are rejected, matching WriteToCompressed.
+type TableProto struct {
+	Version   uint64   // format version, fsstVersion
+	Symbols   [][]byte // learned symbols in code order, 1-8 bytes each
+	SuffixLim uint32   // end of the unique-prefix 2-byte code range
+}
+
+// ToProto exports the table as a TableProto. Returns nil for extended-mode,
+// macro, backref, order-preserving, and frequency-reassigned tables, which
+// the plain field layout cannot represent.
+func (t *Table) ToProto() *TableProto {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 || t.ordered || t.freqRemap != nil {
+		return nil
+	}
+	p := &TableProto{
+		Version:   fsstVersion,
+		Symbols:   make([][]byte, t.nSymbols),
+		SuffixLim: uint32(t.suffixLim),
+	}
+	for i := range int(t.nSymbols) {
+		p.Symbols[i] = t.SymbolOf(i)
+	}
+	return p
+}
+
+// FromProto reconstructs a Table from a TableProto. The result produces
+// byte-identical encode output to the exporting table. Returns ErrBadVersion
+// for an unknown version and ErrBadFormat for symbol counts, lengths, or a
+// suffix limit the format does not allow.
+func FromProto(p *TableProto) (*Table, error) {
+	if p.Version != fsstVersion {
+		return nil, ErrBadVersion
+	}
+	if len(p.Symbols) > fsstMaxSymbols || p.SuffixLim > uint32(len(p.Symbols)) {
+		return nil, ErrBadFormat
+	}
+	t := newTable()
+	t.nSymbols = uint16(len(p.Symbols))
+	t.suffixLim = uint16(p.SuffixLim)
+	for i, sym := range p.Symbols {
+		length := len(sym)
+		if length < 1 || length > 8 {
+			return nil, ErrBadFormat
+		}
+		var val uint64
+		for byteIdx, b := range sym {
+			val |= uint64(b) << (8 * byteIdx)
+		}
+		s := symbol{val: val}
+		s.setCodeLen(uint32(i), uint32(length))
+		t.symbols[i] = s
+		t.lenHisto[length-1]++
+	}
+	t.uniformLen = computeUniformLen(t.lenHisto)
+	return t, nil
+}
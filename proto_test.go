@@ -0,0 +1,55 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestProtoRoundtrip(t *testing.T) {
+	input := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	tbl := Train([][]byte{input})
+
+	p := tbl.ToProto()
+	if p == nil {
+		t.Fatalf("ToProto returned nil for a plain table")
+	}
+	if int(tbl.nSymbols) != len(p.Symbols) {
+		t.Fatalf("proto holds %d symbols, table has %d", len(p.Symbols), tbl.nSymbols)
+	}
+
+	tbl2, err := FromProto(p)
+	if err != nil {
+		t.Fatalf("FromProto: %v", err)
+	}
+	want := tbl.Encode(nil, input)
+	got := tbl2.Encode(nil, input)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("encode output differs after proto roundtrip")
+	}
+	if dec := tbl2.Decode(nil, got); !bytes.Equal(dec, input) {
+		t.Fatalf("decode roundtrip failed after proto roundtrip")
+	}
+}
+
+func TestProtoRejectsInvalid(t *testing.T) {
+	if _, err := FromProto(&TableProto{Version: 1}); !errors.Is(err, ErrBadVersion) {
+		t.Fatalf("bad version accepted: %v", err)
+	}
+	p := &TableProto{Version: fsstVersion, Symbols: [][]byte{{}}}
+	if _, err := FromProto(p); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("empty symbol accepted: %v", err)
+	}
+	p = &TableProto{Version: fsstVersion, Symbols: [][]byte{[]byte("ab")}, SuffixLim: 2}
+	if _, err := FromProto(p); !errors.Is(err, ErrBadFormat) {
+		t.Fatalf("out-of-range suffixLim accepted: %v", err)
+	}
+}
+
+func TestToProtoRejectsExtended(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("extended mode corpus ", 50))}, WithExtendedCodes(true))
+	if p := tbl.ToProto(); p != nil {
+		t.Fatalf("ToProto accepted an extended table")
+	}
+}
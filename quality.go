@@ -0,0 +1,49 @@
+package fsst
+
+// QualityScore condenses the table's shape into a single 0-1 number, so a
+// pipeline can reject weak tables and fall back to raw storage. It is
+// computed from lenHisto, suffixLim, and nSymbols only, so it works on a
+// freshly deserialized table without touching any corpus. The score is a
+// weighted sum of four components:
+//
+//   - average symbol length, normalized as (avg-1)/7 — weight 0.50
+//   - fraction of symbols 4+ bytes long — weight 0.30
+//   - multi-byte fullness: symbols of length 2+ over table capacity (255,
+//     510 extended) — weight 0.10
+//   - suffix coverage: the share of 2-byte symbols with a unique prefix
+//     (suffixLim over the 2-byte count; 1 when there are none) — weight 0.10
+//
+// A table of 255 eight-byte symbols with unique prefixes scores 1.0; an
+// empty table scores 0. A table of nothing but single-byte symbols — what
+// training on random bytes produces — scores 0.10. Structured text typically
+// lands around 0.4-0.7; below ~0.25 most bytes ride single-byte codes or
+// escapes and FSST is unlikely to pay for itself.
+func (t *Table) QualityScore() float64 {
+	if t.nSymbols == 0 {
+		return 0
+	}
+	n := float64(t.nSymbols)
+
+	var lenSum, longCount float64
+	for i, cnt := range t.lenHisto {
+		lenSum += float64(cnt) * float64(i+1)
+		if i+1 >= 4 {
+			longCount += float64(cnt)
+		}
+	}
+	avgNorm := (lenSum/n - 1) / 7
+	longFrac := longCount / n
+
+	suffixCoverage := 1.0
+	if twoByte := float64(t.lenHisto[1]); twoByte > 0 {
+		suffixCoverage = float64(t.suffixLim) / twoByte
+	}
+
+	capacity := float64(fsstMaxSymbols)
+	if t.extended {
+		capacity = float64(fsstMaxSymbolsExtended)
+	}
+	fullness := (n - float64(t.lenHisto[0])) / capacity
+
+	return 0.50*avgNorm + 0.30*longFrac + 0.10*fullness + 0.10*suffixCoverage
+}
@@ -0,0 +1,53 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestQualityScoreBounds(t *testing.T) {
+	if got := newTable().QualityScore(); got != 0 {
+		t.Fatalf("empty table scores %f, want 0", got)
+	}
+
+	tbl := Train([][]byte{[]byte(strings.Repeat("repetitive structured text ", 100))})
+	score := tbl.QualityScore()
+	if score <= 0 || score > 1 {
+		t.Fatalf("score %f out of (0, 1]", score)
+	}
+}
+
+func TestQualityScoreOrdersCorpora(t *testing.T) {
+	structured := Train([][]byte{[]byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))})
+
+	// Near-random bytes: few useful symbols.
+	var noise []byte
+	rng := fsstHash(rngSeed)
+	for i := 0; i < 4000; i++ {
+		rng = fsstHash(rng)
+		noise = append(noise, byte(rng))
+	}
+	noisy := Train([][]byte{noise})
+
+	if s, n := structured.QualityScore(), noisy.QualityScore(); s <= n {
+		t.Fatalf("structured corpus scores %f, noise %f; expected structured to score higher", s, n)
+	}
+}
+
+func TestQualityScoreSurvivesSerialization(t *testing.T) {
+	tbl := Train([][]byte{[]byte(strings.Repeat("persist and reload ", 100))})
+	want := tbl.QualityScore()
+
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFrom(&buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got := tbl2.QualityScore(); got != want {
+		t.Fatalf("score after reload %f, want %f", got, want)
+	}
+}
@@ -0,0 +1,21 @@
+package fsst
+
+// Symbol is the public view of one learned table entry, for tooling that
+// inspects a vocabulary. The internal representation packs code, length and
+// bytes into two words; this unpacks them.
+type Symbol struct {
+	Code  int    // the code Encode emits for this symbol
+	Bytes []byte // the symbol's bytes, 1-8 of them
+}
+
+// Range calls fn for every learned symbol in code order — the stable layout
+// finalize produced: unique-prefix 2-byte symbols, then the remaining multi-
+// byte symbols, then 1-byte symbols. Iteration stops early when fn returns
+// false. The Bytes slice is freshly allocated per symbol, so fn may retain it.
+func (t *Table) Range(fn func(Symbol) bool) {
+	for code := 0; code < int(t.nSymbols); code++ {
+		if !fn(Symbol{Code: code, Bytes: t.SymbolOf(code)}) {
+			return
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	corpus := []byte(strings.Repeat("iterate the learned vocabulary ", 100))
+	tbl := Train([][]byte{corpus})
+
+	var seen []Symbol
+	tbl.Range(func(s Symbol) bool {
+		seen = append(seen, s)
+		return true
+	})
+	if len(seen) != int(tbl.nSymbols) {
+		t.Fatalf("Range visited %d symbols, table has %d", len(seen), tbl.nSymbols)
+	}
+	for i, s := range seen {
+		if s.Code != i {
+			t.Fatalf("symbol %d reported code %d; want code order", i, s.Code)
+		}
+		if !bytes.Equal(s.Bytes, tbl.SymbolOf(i)) {
+			t.Fatalf("code %d: Range bytes differ from SymbolOf", i)
+		}
+		if len(s.Bytes) < 1 || len(s.Bytes) > 8 {
+			t.Fatalf("code %d: symbol length %d out of range", i, len(s.Bytes))
+		}
+	}
+
+	// Early termination stops the walk where fn says so.
+	visited := 0
+	tbl.Range(func(Symbol) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Fatalf("early stop visited %d symbols, want 3", visited)
+	}
+}
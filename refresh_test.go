@@ -0,0 +1,49 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRefreshRebuildsAfterMutation(t *testing.T) {
+	input := []byte(strings.Repeat("abcdef ", 100))
+	tbl := Train([][]byte{input})
+
+	// Warm both directions so all lazy caches exist.
+	comp := tbl.Encode(nil, input)
+	if got := tbl.Decode(nil, comp); !bytes.Equal(got, input) {
+		t.Fatalf("baseline roundtrip failed")
+	}
+
+	// Swap the bytes of a multi-byte symbol in place, keeping its length, so
+	// every cached lookup structure points at the old bytes.
+	target := -1
+	for i := range int(tbl.nSymbols) {
+		if tbl.symbols[i].length() >= 3 {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		t.Skip("corpus produced no 3+ byte symbols")
+	}
+	length := tbl.symbols[target].length()
+	var val uint64
+	for b := 0; b < int(length); b++ {
+		val |= uint64('Q') << (8 * b)
+	}
+	tbl.symbols[target].val = val
+
+	tbl.Refresh()
+
+	// The rebuilt encoder must match the new bytes and roundtrip cleanly.
+	qs := []byte(strings.Repeat("Q", int(length)*20))
+	comp = tbl.Encode(nil, qs)
+	if len(comp) >= len(qs) {
+		t.Fatalf("mutated symbol not used: %d bytes compressed to %d", len(qs), len(comp))
+	}
+	if got := tbl.Decode(nil, comp); !bytes.Equal(got, qs) {
+		t.Fatalf("roundtrip after Refresh: got %q, want %q", got, qs)
+	}
+}
@@ -0,0 +1,91 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestReloadSameStruct reuses one Table struct across ReadFrom calls and
+// checks that nothing from the first table's life leaks into the second:
+// ReadFrom resets the whole struct via newTable, so scratch buffers, strategy
+// flags, and mode flags (macros, backrefs, ordered) must all be rebuilt for
+// the reloaded table.
+func TestReloadSameStruct(t *testing.T) {
+	corpusA := [][]byte{[]byte(strings.Repeat("alpha beta gamma delta ", 100))}
+	corpusB := [][]byte{[]byte(strings.Repeat("zero one two three four ", 100))}
+	tblA := Train(corpusA)
+	tblB := Train(corpusB)
+
+	var bufA, bufB bytes.Buffer
+	if _, err := tblA.WriteTo(&bufA); err != nil {
+		t.Fatalf("WriteTo A: %v", err)
+	}
+	if _, err := tblB.WriteTo(&bufB); err != nil {
+		t.Fatalf("WriteTo B: %v", err)
+	}
+
+	inputA := []byte("alpha beta gamma")
+	inputB := []byte("zero one two")
+
+	var reused Table
+	if _, err := reused.ReadFrom(&bufA); err != nil {
+		t.Fatalf("ReadFrom A: %v", err)
+	}
+	// Encode warms encBuf and the strategy flags for A's symbol statistics.
+	encA := reused.Encode(nil, inputA)
+	if !bytes.Equal(encA, tblA.Encode(nil, inputA)) {
+		t.Fatal("reloaded A encodes differently from the original")
+	}
+	if got := reused.Decode(nil, encA); !bytes.Equal(got, inputA) {
+		t.Fatalf("reloaded A roundtrip = %q, want %q", got, inputA)
+	}
+
+	if _, err := reused.ReadFrom(&bufB); err != nil {
+		t.Fatalf("ReadFrom B: %v", err)
+	}
+	encB := reused.Encode(nil, inputB)
+	if !bytes.Equal(encB, tblB.Encode(nil, inputB)) {
+		t.Fatal("reloaded B encodes differently from the original: stale state from A's life")
+	}
+	if got := reused.Decode(nil, encB); !bytes.Equal(got, inputB) {
+		t.Fatalf("reloaded B roundtrip = %q, want %q", got, inputB)
+	}
+	if !reused.Equal(tblB) {
+		t.Fatal("reused struct does not equal freshly trained B after reload")
+	}
+}
+
+// TestReloadAcrossModes loads tables of different modes into one struct and
+// verifies the mode flags never survive a reload: a backref table followed by
+// a plain one must not emit markers, and an ordered table followed by a plain
+// one must go back to the standard encoder.
+func TestReloadAcrossModes(t *testing.T) {
+	corpus := [][]byte{[]byte(strings.Repeat("the quick brown fox jumps over the lazy dog ", 50))}
+	plain := Train(corpus)
+	backref := Train(corpus, WithBackrefs(64))
+	ordered := TrainOrderPreserving(corpus)
+
+	serialize := func(tbl *Table) *bytes.Buffer {
+		var buf bytes.Buffer
+		if _, err := tbl.WriteTo(&buf); err != nil {
+			t.Fatalf("WriteTo: %v", err)
+		}
+		return &buf
+	}
+	input := bytes.Repeat([]byte("the quick brown fox "), 8)
+
+	var reused Table
+	for _, want := range []*Table{backref, plain, ordered, plain, backref} {
+		if _, err := reused.ReadFrom(serialize(want)); err != nil {
+			t.Fatalf("ReadFrom: %v", err)
+		}
+		enc := reused.Encode(nil, input)
+		if !bytes.Equal(enc, want.Encode(nil, input)) {
+			t.Fatal("reloaded table encodes differently after a mode switch")
+		}
+		if got := reused.Decode(nil, enc); !bytes.Equal(got, input) {
+			t.Fatalf("roundtrip after mode switch = %q, want %q", got, input)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncodeAppendAt(t *testing.T) {
+	input := []byte(strings.Repeat("log line with fields ", 10))
+	tbl := Train([][]byte{input})
+	want := tbl.Encode(nil, input)
+
+	ring := make([]byte, 4096)
+	off := 17 // arbitrary start position
+	newOff := tbl.EncodeAppendAt(ring, off, input)
+	if got := ring[off:newOff]; !bytes.Equal(got, want) {
+		t.Fatalf("EncodeAppendAt output differs from Encode")
+	}
+
+	// Consecutive writes pack records back to back.
+	newOff2 := tbl.EncodeAppendAt(ring, newOff, input)
+	if got := ring[newOff:newOff2]; !bytes.Equal(got, want) {
+		t.Fatalf("second record differs from Encode")
+	}
+}
+
+func TestEncodeAppendAtExactFit(t *testing.T) {
+	input := []byte("exact fit")
+	tbl := Train([][]byte{input})
+
+	dst := make([]byte, 2*len(input)+fsstOutputPadding)
+	newOff := tbl.EncodeAppendAt(dst, 0, input)
+	if got, want := dst[:newOff], tbl.Encode(nil, input); !bytes.Equal(got, want) {
+		t.Fatalf("exact-fit output differs from Encode")
+	}
+}
+
+func TestEncodeAppendAtPanicsWhenTooSmall(t *testing.T) {
+	input := []byte("does not fit")
+	tbl := Train([][]byte{input})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic on undersized destination")
+		}
+	}()
+	dst := make([]byte, 2*len(input)+fsstOutputPadding-1)
+	tbl.EncodeAppendAt(dst, 0, input)
+}
+
+func TestEncodeAppendAtAllocs(t *testing.T) {
+	input := []byte(strings.Repeat("steady state ", 20))
+	tbl := Train([][]byte{input})
+	ring := make([]byte, 4096)
+	tbl.EncodeAppendAt(ring, 0, input) // warm lazy encoder state
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tbl.EncodeAppendAt(ring, 0, input)
+	})
+	if allocs != 0 {
+		t.Fatalf("EncodeAppendAt allocates %v times per run, want 0", allocs)
+	}
+}
@@ -0,0 +1,66 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSymbolRuneAligned(t *testing.T) {
+	cases := []struct {
+		bytes []byte
+		want  bool
+	}{
+		{[]byte("abc"), true},
+		{[]byte("日"), true},          // complete 3-byte rune
+		{[]byte("日本"), true},         // two complete runes
+		{[]byte("a日"), true},         // ASCII + complete rune
+		{[]byte("日")[:2], false},     // truncated trailing rune
+		{[]byte("日")[1:], false},     // starts on continuation byte
+		{[]byte{0x80}, true},         // single byte is always aligned
+		{[]byte{'a', 0x80}, false},   // stray continuation mid-symbol
+		{[]byte{0xC3, 0xA9}, true},   // é
+		{[]byte{'a', 0xC3}, false},   // é missing its continuation
+	}
+	for _, tc := range cases {
+		if got := symbolRuneAligned(newSymbolFromBytes(tc.bytes)); got != tc.want {
+			t.Errorf("symbolRuneAligned(% x) = %v, want %v", tc.bytes, got, tc.want)
+		}
+	}
+}
+
+// countStraddling returns how many learned multi-byte symbols straddle rune
+// boundaries.
+func countStraddling(tbl *Table) int {
+	straddling := 0
+	for code := range int(tbl.nSymbols) {
+		if !symbolRuneAligned(tbl.symbols[code]) {
+			straddling++
+		}
+	}
+	return straddling
+}
+
+func TestRuneAlignedTraining(t *testing.T) {
+	// A varied CJK corpus, so candidates outnumber table slots and the gain
+	// penalty actually creates selection pressure.
+	words := []string{"日本語", "圧縮", "例文", "標準", "記号", "辞書", "学習", "検証", "言語", "処理"}
+	var inputs [][]byte
+	for i := 0; i < 400; i++ {
+		line := words[i%len(words)] + "の" + words[(i*7)%len(words)] + "と" + words[(i*13)%len(words)] + " "
+		inputs = append(inputs, []byte(line))
+	}
+	plain := Train(inputs)
+	aligned := Train(inputs, WithRuneAlignedSymbols(true))
+
+	if p, a := countStraddling(plain), countStraddling(aligned); a > p {
+		t.Errorf("rune-aligned training produced %d straddling symbols, plain %d", a, p)
+	}
+
+	// Heuristic only: roundtrip and wire format are untouched.
+	input := []byte(strings.Repeat("日本語のテキスト", 10))
+	comp := aligned.Encode(nil, input)
+	if got := aligned.Decode(nil, comp); !bytes.Equal(got, input) {
+		t.Fatalf("roundtrip mismatch with rune-aligned table")
+	}
+}
@@ -0,0 +1,31 @@
+package fsst
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCorrupt indicates SafeDecode found the compressed stream malformed.
+var ErrCorrupt = errors.New("fsst: corrupt compressed stream")
+
+// SafeDecode decompresses fully untrusted bytes with a no-panic guarantee:
+// any anomaly in the stream — a truncated trailing escape, codes outside the
+// learned range, dangling macro indexes, out-of-window back-references —
+// returns an error wrapping ErrCorrupt instead of silently skipping or
+// crashing, and a recover guard turns even an unexpected decoder panic into
+// that error. It walks the stream with the validating decoder, so it is
+// slower than Decode; keep Decode for trusted input. The decoded bytes are
+// returned alongside the error so callers who want best-effort recovery have
+// them (DecodeLossy reports every anomaly if one is not enough).
+func (t *Table) SafeDecode(src []byte) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, fmt.Errorf("%w: decoder panic: %v", ErrCorrupt, r)
+		}
+	}()
+	out, errs := t.DecodeLossy(src)
+	if len(errs) > 0 {
+		return out, fmt.Errorf("%w: %v", ErrCorrupt, errs[0])
+	}
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSafeDecode(t *testing.T) {
+	corpus := []byte(strings.Repeat("defended decode for untrusted input ", 100))
+	tbl := Train([][]byte{corpus})
+
+	// A valid stream decodes identically to Decode with a nil error.
+	comp := tbl.Encode(nil, corpus)
+	out, err := tbl.SafeDecode(comp)
+	if err != nil {
+		t.Fatalf("SafeDecode on valid stream: %v", err)
+	}
+	if !bytes.Equal(out, tbl.Decode(nil, comp)) {
+		t.Fatal("SafeDecode output differs from Decode")
+	}
+
+	// Empty input is fine: no bytes, no error.
+	if out, err := tbl.SafeDecode(nil); err != nil || len(out) != 0 {
+		t.Fatalf("SafeDecode(nil) = %d bytes, %v; want empty, nil", len(out), err)
+	}
+
+	// A trailing escape with no literal is an anomaly, not a crash.
+	if _, err := tbl.SafeDecode([]byte{fsstEscapeCode}); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("trailing escape: err = %v, want ErrCorrupt", err)
+	}
+
+	// A code past the learned symbols is an anomaly too; the decoded bytes
+	// before it are still returned for best-effort callers.
+	bad := append(append([]byte{}, comp...), 254)
+	out, err = tbl.SafeDecode(bad)
+	if !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("unknown code: err = %v, want ErrCorrupt", err)
+	}
+	if !bytes.Equal(out, corpus) {
+		t.Fatal("SafeDecode dropped the valid prefix on anomaly")
+	}
+}
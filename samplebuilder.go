@@ -0,0 +1,54 @@
+package fsst
+
+// SampleBuilder accumulates a bounded reservoir sample over many batches of
+// inputs, so one table can be trained across a streamed dataset — e.g. many
+// small columnar pages — without materializing it. Chunks of up to 512 bytes
+// are reservoir-sampled: once the 16KB budget is full, each new chunk
+// replaces a random held chunk with probability k/n, giving every chunk seen
+// an equal chance of surviving regardless of arrival order volume. Sampling
+// is deterministic: the same pages in the same order produce the same sample
+// and therefore the same table.
+type SampleBuilder struct {
+	chunks [][]byte // reservoir, each entry an owned copy of one chunk
+	seen   int      // chunks offered so far
+	rng    uint64
+}
+
+// NewSampleBuilder returns an empty builder with the default deterministic
+// seed, matching Train's sampling.
+func NewSampleBuilder() *SampleBuilder {
+	return &SampleBuilder{rng: fsstHash(rngSeed)}
+}
+
+// Add offers every input of a page to the reservoir.
+func (b *SampleBuilder) Add(inputs [][]byte) {
+	const maxChunks = fsstSampleTarget / fsstSampleLine
+	for _, input := range inputs {
+		for off := 0; off < len(input); off += fsstSampleLine {
+			chunk := input[off:min(off+fsstSampleLine, len(input))]
+			if len(b.chunks) < maxChunks {
+				owned := make([]byte, len(chunk))
+				copy(owned, chunk)
+				b.chunks = append(b.chunks, owned)
+			} else {
+				b.rng = fsstHash(b.rng)
+				if j := int(b.rng % uint64(b.seen+1)); j < maxChunks {
+					owned := b.chunks[j][:0]
+					b.chunks[j] = append(owned, chunk...)
+				}
+			}
+			b.seen++
+		}
+	}
+}
+
+// Train builds a table from the accumulated sample. The builder can keep
+// accepting pages afterwards; a later Train sees the updated reservoir.
+func (b *SampleBuilder) Train(opts ...TrainOption) *Table {
+	var cfg trainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	table, _ := trainFromSample(nil, b.chunks, cfg)
+	return table
+}
@@ -0,0 +1,58 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSampleBuilder(t *testing.T) {
+	page1 := [][]byte{[]byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 60))}
+	page2 := [][]byte{[]byte(strings.Repeat(`{"level":"warn","msg":"slow request"}`, 60))}
+
+	b := NewSampleBuilder()
+	b.Add(page1)
+	b.Add(page2)
+
+	// The reservoir stays within the sample budget.
+	var total int
+	for _, chunk := range b.chunks {
+		total += len(chunk)
+	}
+	if total > fsstSampleTarget {
+		t.Fatalf("reservoir holds %d bytes, budget is %d", total, fsstSampleTarget)
+	}
+
+	// The table serves vocabulary from both pages.
+	tbl := b.Train()
+	for i, page := range [][][]byte{page1, page2} {
+		comp := tbl.Encode(nil, page[0])
+		if len(comp) >= len(page[0])/2 {
+			t.Fatalf("page %d poorly compressed: %d -> %d bytes", i, len(page[0]), len(comp))
+		}
+		if got := tbl.Decode(nil, comp); !bytes.Equal(got, page[0]) {
+			t.Fatalf("page %d roundtrip failed", i)
+		}
+	}
+
+	// Determinism: same pages, same order, same table.
+	b2 := NewSampleBuilder()
+	b2.Add(page1)
+	b2.Add(page2)
+	if !tbl.Equal(b2.Train()) {
+		t.Fatalf("sample builder training is not deterministic")
+	}
+}
+
+func TestSampleBuilderReservoirBounded(t *testing.T) {
+	b := NewSampleBuilder()
+	for i := 0; i < 100; i++ {
+		b.Add([][]byte{noiseBlob(4096)})
+	}
+	if max := fsstSampleTarget / fsstSampleLine; len(b.chunks) > max {
+		t.Fatalf("reservoir holds %d chunks, cap is %d", len(b.chunks), max)
+	}
+	if b.seen != 100*(4096/fsstSampleLine) {
+		t.Fatalf("seen %d chunks, want %d", b.seen, 100*(4096/fsstSampleLine))
+	}
+}
@@ -0,0 +1,53 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSampleLine(t *testing.T) {
+	// One giant input, so every slice comes from the same record.
+	big := bytes.Repeat([]byte("{\"level\":\"info\",\"msg\":\"sampled with a custom window\"}"), 2000)
+	inputs := [][]byte{big}
+
+	// A larger window yields larger slices while the total stays on budget.
+	sample := makeSampleLined(inputs, fsstSampleTarget, 4096)
+	total := 0
+	for _, chunk := range sample {
+		if len(chunk) > 4096 {
+			t.Fatalf("slice of %d bytes exceeds the 4096-byte window", len(chunk))
+		}
+		total += len(chunk)
+	}
+	if total < fsstSampleTarget || total > 2*fsstSampleTarget {
+		t.Fatalf("sample totals %d bytes, want near %d", total, fsstSampleTarget)
+	}
+
+	// A window beyond the target is clamped rather than starving the sample.
+	sample = makeSampleLined(inputs, fsstSampleTarget, 10*fsstSampleTarget)
+	total = 0
+	for _, chunk := range sample {
+		total += len(chunk)
+	}
+	if total < fsstSampleTarget {
+		t.Fatalf("clamped sample totals %d bytes, want at least %d", total, fsstSampleTarget)
+	}
+
+	// n <= 0 keeps the default: the table matches a plain Train run.
+	plain := Train(inputs)
+	dflt := Train(inputs, WithSampleLine(0))
+	if !plain.Equal(dflt) {
+		t.Fatal("WithSampleLine(0) changed the trained table")
+	}
+
+	// Training with a custom window is deterministic and round-trips.
+	a := Train(inputs, WithSampleLine(2048))
+	b := Train(inputs, WithSampleLine(2048))
+	if !a.Equal(b) {
+		t.Fatal("training with WithSampleLine is not deterministic")
+	}
+	comp := a.Encode(nil, big[:5000])
+	if got := a.Decode(nil, comp); !bytes.Equal(got, big[:5000]) {
+		t.Fatal("roundtrip failed with a custom sample line")
+	}
+}
@@ -0,0 +1,118 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrScatterOverflow indicates the scatter buffers filled up before the
+// compressed stream was fully decoded.
+var ErrScatterOverflow = errors.New("fsst: scatter buffers too small for decoded output")
+
+// DecodeScatter decompresses src into bufs in order, spilling into the next
+// buffer when one fills; a symbol may straddle two (or more) buffers. It
+// returns the total decoded bytes written. When the buffers run out before
+// the stream ends it returns ErrScatterOverflow with the buffers fully
+// written, so the caller can tell exactly where decoding stopped. Built for
+// stacks where output must land in pre-registered fixed buffers (iovec/RDMA
+// pools) rather than one contiguous slice.
+func (t *Table) DecodeScatter(src []byte, bufs [][]byte) (total int, err error) {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	var (
+		bufIdx int
+		bufPos int
+		tmp    [8]byte
+	)
+	// emit copies length bytes of val across buffer boundaries; it reports
+	// false when the buffers are exhausted mid-write.
+	emit := func(val uint64, length int) bool {
+		binary.LittleEndian.PutUint64(tmp[:], val)
+		written := 0
+		for written < length {
+			for bufIdx < len(bufs) && bufPos == len(bufs[bufIdx]) {
+				bufIdx++
+				bufPos = 0
+			}
+			if bufIdx == len(bufs) {
+				return false
+			}
+			n := copy(bufs[bufIdx][bufPos:], tmp[written:length])
+			bufPos += n
+			written += n
+			total += n
+		}
+		return true
+	}
+
+	for srcPos := 0; srcPos < len(src); {
+		code := uint16(src[srcPos])
+		srcPos++
+		switch {
+		case code == fsstEscapeCode:
+			if srcPos >= len(src) {
+				return total, nil
+			}
+			if !emit(uint64(src[srcPos]), 1) {
+				return total, ErrScatterOverflow
+			}
+			srcPos++
+		case t.extended:
+			if code == fsstExtendedPrefix {
+				if srcPos >= len(src) {
+					return total, nil
+				}
+				code = fsstExtendedPrefix + uint16(src[srcPos])
+				srcPos++
+			}
+			if int(code) < len(t.decLenExt) {
+				if !emit(t.decSymbolExt[code], int(t.decLenExt[code])) {
+					return total, ErrScatterOverflow
+				}
+			}
+		case t.hasMacros && code >= t.macroBase:
+			if idx := code - t.macroBase; int(idx) < len(t.macros) {
+				for _, c := range t.macros[idx] {
+					if !emit(t.decSymbol[c], int(t.decLen[c])) {
+						return total, ErrScatterOverflow
+					}
+				}
+			}
+		case t.backrefWindow > 0 && code == t.nSymbols:
+			if srcPos+1 >= len(src) {
+				return total, nil
+			}
+			dist := int(src[srcPos])
+			length := int(src[srcPos+1])
+			srcPos += 2
+			if dist < 1 || dist > total {
+				continue
+			}
+			for range length {
+				// Read back the byte at absolute output offset total-dist;
+				// the buffers before the write position are fully filled.
+				abs := total - dist
+				var b byte
+				for i := range bufs {
+					if abs < len(bufs[i]) {
+						b = bufs[i][abs]
+						break
+					}
+					abs -= len(bufs[i])
+				}
+				if !emit(uint64(b), 1) {
+					return total, ErrScatterOverflow
+				}
+			}
+		default:
+			if !emit(t.decSymbol[code], int(t.decLen[code])) {
+				return total, ErrScatterOverflow
+			}
+		}
+	}
+	return total, nil
+}
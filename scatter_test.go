@@ -0,0 +1,49 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecodeScatter(t *testing.T) {
+	input := []byte(strings.Repeat("scatter gather decode path ", 40))
+	tbl := Train([][]byte{input})
+	comp := tbl.Encode(nil, input)
+
+	// Odd buffer sizes force symbols to straddle boundaries.
+	bufs := [][]byte{
+		make([]byte, 7),
+		make([]byte, 100),
+		make([]byte, 3),
+		make([]byte, len(input)),
+	}
+	total, err := tbl.DecodeScatter(comp, bufs)
+	if err != nil {
+		t.Fatalf("DecodeScatter: %v", err)
+	}
+	if total != len(input) {
+		t.Fatalf("decoded %d bytes, want %d", total, len(input))
+	}
+	var joined []byte
+	for _, buf := range bufs {
+		joined = append(joined, buf...)
+	}
+	if !bytes.Equal(joined[:total], input) {
+		t.Fatalf("scattered output differs from input")
+	}
+
+	// Undersized buffers: full buffers, overflow error.
+	small := [][]byte{make([]byte, 10), make([]byte, 10)}
+	total, err = tbl.DecodeScatter(comp, small)
+	if !errors.Is(err, ErrScatterOverflow) {
+		t.Fatalf("overflow not reported: %v", err)
+	}
+	if total != 20 {
+		t.Fatalf("overflow wrote %d bytes, want 20", total)
+	}
+	if want := tbl.Decode(nil, comp); !bytes.Equal(append(small[0], small[1]...), want[:20]) {
+		t.Fatalf("overflow output prefix differs from full decode")
+	}
+}
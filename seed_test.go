@@ -0,0 +1,70 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithSeedSymbols(t *testing.T) {
+	corpus := []byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 100))
+	prev := Train([][]byte{corpus})
+
+	// Retraining on similar data with seeds: useful seeds must survive.
+	next := Train([][]byte{corpus}, WithSeedSymbols(prev, 32))
+
+	longest := make(map[string]bool)
+	for length := 8; length >= 2 && len(longest) < 32; length-- {
+		for i := 0; i < int(prev.nSymbols) && len(longest) < 32; i++ {
+			if int(prev.symbols[i].length()) == length {
+				longest[string(prev.SymbolOf(i))] = true
+			}
+		}
+	}
+	surviving := 0
+	for i := 0; i < int(next.nSymbols); i++ {
+		if longest[string(next.SymbolOf(i))] {
+			surviving++
+		}
+	}
+	if surviving < len(longest)/2 {
+		t.Fatalf("only %d of %d seeded symbols survived retraining on the same data", surviving, len(longest))
+	}
+
+	// Seeds must not break roundtrips or determinism.
+	comp := next.Encode(nil, corpus)
+	if got := next.Decode(nil, comp); !bytes.Equal(got, corpus) {
+		t.Fatalf("roundtrip failed with seeds")
+	}
+	if !next.Equal(Train([][]byte{corpus}, WithSeedSymbols(prev, 32))) {
+		t.Fatalf("seeded training is not deterministic")
+	}
+
+	// Seeding from an unrelated table must not pin useless symbols: seeds the
+	// new data never uses carry only a small synthetic floor and must lose to
+	// fresh candidates. The alien corpus avoids English fragments so its
+	// symbols cannot earn real gains on prose.
+	alien := Train([][]byte{[]byte(strings.Repeat("@@##$$%%^^&&**[[]]__++~~||", 100))})
+	other, err := os.ReadFile("testdata/en_mobydick.txt")
+	if err != nil {
+		t.Skip("corpus not available")
+	}
+	other = other[:1<<17]
+	alienSyms := make(map[string]bool)
+	for i := 0; i < int(alien.nSymbols); i++ {
+		if alien.symbols[i].length() >= 2 {
+			alienSyms[string(alien.SymbolOf(i))] = true
+		}
+	}
+	reseeded := Train([][]byte{other}, WithSeedSymbols(alien, 32))
+	foreign := 0
+	for i := 0; i < int(reseeded.nSymbols); i++ {
+		if alienSyms[string(reseeded.SymbolOf(i))] {
+			foreign++
+		}
+	}
+	if foreign > len(alienSyms)/2 {
+		t.Fatalf("%d of %d foreign seeds survived on unrelated data", foreign, len(alienSyms))
+	}
+}
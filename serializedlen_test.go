@@ -0,0 +1,59 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSerializedLen(t *testing.T) {
+	corpus := []byte(strings.Repeat("serialized length of packed tables ", 100))
+	tables := map[string]*Table{
+		"plain":    Train([][]byte{corpus}),
+		"extended": Train([][]byte{corpus}, WithExtendedCodes(true)),
+		"macros":   Train([][]byte{corpus}, WithMacros(true)),
+	}
+	for name, tbl := range tables {
+		var buf bytes.Buffer
+		n, err := tbl.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("%s: WriteTo: %v", name, err)
+		}
+		if got := tbl.SerializedLen(); got != int(n) || got != buf.Len() {
+			t.Fatalf("%s: SerializedLen %d, WriteTo wrote %d bytes", name, got, n)
+		}
+		blob, err := tbl.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: MarshalBinary: %v", name, err)
+		}
+		if got := tbl.SerializedLen(); got != len(blob) {
+			t.Fatalf("%s: SerializedLen %d, MarshalBinary %d bytes", name, got, len(blob))
+		}
+	}
+}
+
+func TestSerializedLenPacking(t *testing.T) {
+	first := Train([][]byte{[]byte(strings.Repeat("first table corpus ", 80))})
+	second := Train([][]byte{[]byte(strings.Repeat(`{"second":"table"}`, 80))})
+
+	// Pack both tables back-to-back; SerializedLen gives the second offset.
+	var file bytes.Buffer
+	first.WriteTo(&file)
+	offset := file.Len()
+	if offset != first.SerializedLen() {
+		t.Fatalf("offset %d, SerializedLen %d", offset, first.SerializedLen())
+	}
+	second.WriteTo(&file)
+
+	blob := file.Bytes()
+	var a, b Table
+	if _, err := a.ReadFrom(bytes.NewReader(blob)); err != nil {
+		t.Fatalf("read first: %v", err)
+	}
+	if _, err := b.ReadFrom(bytes.NewReader(blob[offset:])); err != nil {
+		t.Fatalf("read second at offset: %v", err)
+	}
+	if !a.Equal(first) || !b.Equal(second) {
+		t.Fatalf("packed tables did not roundtrip")
+	}
+}
@@ -0,0 +1,41 @@
+package fsst
+
+import "encoding/binary"
+
+// DecodeShort decompresses src straight into dst and returns the number of
+// decoded bytes, with none of Decode's buffer setup or growth logic. It is
+// built for dictionary workloads decoding tiny values (enum strings of a few
+// codes) millions of times, where that per-call overhead dominates; see
+// BenchmarkDecodeShort. The caller guarantees room for the full output plus
+// 8-byte store slack — len(dst) >= 8*len(src) always suffices — and the
+// method panics if that contract is broken. For extended, macro, and backref
+// tables it falls back to the generic decoder (their codes can expand past
+// the 8x bound; size dst with DecodedLen) and loses the speed advantage.
+func (t *Table) DecodeShort(dst, src []byte) int {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 {
+		return copy(dst, t.Decode(nil, src))
+	}
+	if len(dst) < 8*len(src) {
+		panic("fsst: DecodeShort destination too small for worst-case output")
+	}
+	t.ensureDecoder()
+
+	pos := 0
+	for srcPos := 0; srcPos < len(src); {
+		code := src[srcPos]
+		srcPos++
+		if code == fsstEscapeCode {
+			if srcPos >= len(src) {
+				break
+			}
+			dst[pos] = src[srcPos]
+			pos++
+			srcPos++
+			continue
+		}
+		// Unconditional 8-byte store; dst has slack for the unused tail.
+		binary.LittleEndian.PutUint64(dst[pos:], t.decSymbol[code])
+		pos += int(t.decLen[code])
+	}
+	return pos
+}
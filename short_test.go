@@ -0,0 +1,70 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeShort(t *testing.T) {
+	corpus := []byte(strings.Repeat("ACTIVE INACTIVE PENDING DELETED ", 100))
+	tbl := Train([][]byte{corpus})
+
+	inputs := [][]byte{
+		[]byte("ACTIVE"),
+		[]byte("PENDING"),
+		[]byte("DELETED"),
+		[]byte("x"), // escapes: not in the corpus
+		[]byte("A\xffZ"),
+		{},
+	}
+	var dst [128]byte
+	for _, input := range inputs {
+		comp := tbl.Encode(nil, input)
+		n := tbl.DecodeShort(dst[:], comp)
+		if !bytes.Equal(dst[:n], input) {
+			t.Fatalf("DecodeShort(%q) = %q, want %q", comp, dst[:n], input)
+		}
+	}
+
+	// The capacity contract is enforced, not silently violated.
+	comp := tbl.Encode(nil, []byte("ACTIVE"))
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for undersized dst")
+		}
+	}()
+	tbl.DecodeShort(make([]byte, 3), comp)
+}
+
+func BenchmarkDecodeShort(b *testing.B) {
+	corpus := []byte(strings.Repeat("ACTIVE INACTIVE PENDING DELETED ", 100))
+	tbl := Train([][]byte{corpus})
+	tbl.Prepare()
+
+	var comps [][]byte
+	var total int
+	for _, v := range []string{"ACTIVE", "INACTIVE", "PENDING", "DELETED"} {
+		comps = append(comps, tbl.Encode(nil, []byte(v)))
+		total += len(v)
+	}
+
+	b.Run("DecodeShort", func(b *testing.B) {
+		var dst [128]byte
+		b.SetBytes(int64(total))
+		for i := 0; i < b.N; i++ {
+			for _, comp := range comps {
+				_ = tbl.DecodeShort(dst[:], comp)
+			}
+		}
+	})
+	b.Run("Decode", func(b *testing.B) {
+		dst := make([]byte, 0, 128)
+		b.SetBytes(int64(total))
+		for i := 0; i < b.N; i++ {
+			for _, comp := range comps {
+				dst = tbl.Decode(dst, comp)
+			}
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package fsst
+
+// SkipSymbols advances past codes in src until at least n decoded bytes have
+// been accounted for, without producing output. It returns how many source
+// bytes were consumed and how many decoded bytes those codes produce; because
+// symbols are atomic, bytesProduced may overshoot n by up to one symbol, and
+// falls short only when src is exhausted. Escape codes consume two source
+// bytes and produce one. Use it to seek into a compressed blob and to build
+// sparse indexes over compressed columns.
+func (t *Table) SkipSymbols(src []byte, n int) (srcConsumed, bytesProduced int) {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	srcPos := 0
+	for srcPos < len(src) && bytesProduced < n {
+		code := uint16(src[srcPos])
+		srcPos++
+		switch {
+		case code == fsstEscapeCode:
+			if srcPos >= len(src) {
+				return srcPos, bytesProduced
+			}
+			bytesProduced++
+			srcPos++
+		case t.extended:
+			if code == fsstExtendedPrefix {
+				if srcPos >= len(src) {
+					return srcPos, bytesProduced
+				}
+				code = fsstExtendedPrefix + uint16(src[srcPos])
+				srcPos++
+			}
+			if int(code) < len(t.decLenExt) {
+				bytesProduced += int(t.decLenExt[code])
+			}
+		case t.hasMacros && code >= t.macroBase:
+			if idx := code - t.macroBase; int(idx) < len(t.macros) {
+				pair := t.macros[idx]
+				bytesProduced += int(t.decLen[pair[0]]) + int(t.decLen[pair[1]])
+			}
+		case t.backrefWindow > 0 && code == t.nSymbols:
+			if srcPos+1 >= len(src) {
+				return srcPos, bytesProduced
+			}
+			bytesProduced += int(src[srcPos+1]) // triple: marker, distance, length
+			srcPos += 2
+		default:
+			bytesProduced += int(t.decLen[code])
+		}
+	}
+	return srcPos, bytesProduced
+}
@@ -0,0 +1,56 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSkipSymbols(t *testing.T) {
+	input := []byte(strings.Repeat("seek into compressed data ", 40))
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+
+	for _, n := range []int{0, 1, 13, 100, len(input)} {
+		consumed, produced := tbl.SkipSymbols(compressed, n)
+		if produced < n && consumed < len(compressed) {
+			t.Fatalf("skip(%d) stopped early: consumed %d, produced %d", n, consumed, produced)
+		}
+		// The decoded remainder plus the skipped prefix must reconstruct the input.
+		rest := tbl.Decode(nil, compressed[consumed:])
+		if got := append(bytes.Clone(input[:produced]), rest...); !bytes.Equal(got, input) {
+			t.Fatalf("skip(%d): prefix of %d bytes + remainder does not reconstruct input", n, produced)
+		}
+		// Overshoot is bounded by one symbol (at most 8 bytes).
+		if produced > n+8 {
+			t.Fatalf("skip(%d) overshot to %d", n, produced)
+		}
+	}
+}
+
+func TestSkipSymbolsPastEnd(t *testing.T) {
+	input := []byte("short")
+	tbl := Train([][]byte{input})
+	compressed := tbl.Encode(nil, input)
+
+	consumed, produced := tbl.SkipSymbols(compressed, 10_000)
+	if consumed != len(compressed) || produced != len(input) {
+		t.Fatalf("skip past end: consumed %d/%d, produced %d/%d",
+			consumed, len(compressed), produced, len(input))
+	}
+}
+
+func TestSkipSymbolsEscapes(t *testing.T) {
+	// A table trained on unrelated data escapes every byte of this input.
+	tbl := Train([][]byte{bytes.Repeat([]byte("zzzz"), 50)})
+	input := []byte("ABCDEF")
+	compressed := tbl.Encode(nil, input)
+
+	consumed, produced := tbl.SkipSymbols(compressed, 3)
+	if produced != 3 {
+		t.Fatalf("produced = %d, want 3", produced)
+	}
+	if consumed != 6 {
+		t.Fatalf("consumed = %d, want 6 (two source bytes per escape)", consumed)
+	}
+}
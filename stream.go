@@ -0,0 +1,161 @@
+package fsst
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// fsstStreamFlushSize is the decoded-bytes threshold at which streaming
+// helpers hand a chunk to the destination writer.
+const fsstStreamFlushSize = 4096
+
+// EncodeStream compresses everything read from r and writes the encoded
+// bytes to w, without loading the input into memory. Reads are processed in
+// fsstChunkSize pieces; up to 7 trailing bytes per piece are carried over to
+// the next read so symbol matches never straddle a read boundary unsafely.
+// It returns the total number of encoded bytes written.
+func (t *Table) EncodeStream(w io.Writer, r io.Reader) (int64, error) {
+	t.ensureEncoder()
+	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+
+	var (
+		total  int64
+		filled int
+		in     = make([]byte, fsstChunkSize+fsstChunkPadding)
+		out    = make([]byte, 2*fsstChunkSize+fsstOutputPadding)
+	)
+	for {
+		n, err := io.ReadFull(r, in[filled:fsstChunkSize])
+		filled += n
+		eof := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !eof {
+			return total, err
+		}
+
+		var end int
+		if eof {
+			// Last piece: encode everything, with zeroed padding for the
+			// unaligned loads near the end.
+			clear(in[filled : filled+8])
+			end = filled
+		} else {
+			// Keep 7 bytes back so an 8-byte match can complete; a match
+			// starting before end may still run into them (limit = filled),
+			// which keeps the stream byte-identical to Encode.
+			end = filled - 7
+		}
+		if end > 0 {
+			outPos, consumed := t.encodeChunk(out, 0, in, end, filled, byteLim)
+			if t.freqRemap != nil {
+				// Each chunk emits complete code/escape pairs, so the remap
+				// pass never straddles a write boundary.
+				t.remapWireCodes(out[:outPos])
+			}
+			nn, werr := w.Write(out[:outPos])
+			total += int64(nn)
+			if werr != nil {
+				return total, werr
+			}
+			copy(in, in[consumed:filled])
+			filled -= consumed
+		}
+		if eof {
+			return total, nil
+		}
+	}
+}
+
+// DecodeToWriter decompresses src and streams the output to w in chunks,
+// so large payloads never materialize fully in memory. It returns the total
+// number of decoded bytes written and the first writer error encountered.
+func (t *Table) DecodeToWriter(w io.Writer, src []byte) (int64, error) {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	var (
+		total int64
+		tmp   [8]byte
+	)
+	// Back-references copy from recent output, so backref tables keep the
+	// last window bytes unflushed until the stream ends.
+	keep := 0
+	if t.backrefWindow > 0 {
+		keep = int(t.backrefWindow)
+	}
+	chunk := make([]byte, 0, fsstStreamFlushSize+8)
+	flush := func(final bool) error {
+		held := keep
+		if final {
+			held = 0
+		}
+		if len(chunk) <= held {
+			return nil
+		}
+		n := len(chunk) - held
+		nn, err := w.Write(chunk[:n])
+		total += int64(nn)
+		copy(chunk, chunk[n:])
+		chunk = chunk[:held]
+		return err
+	}
+
+loop:
+	for srcPos := 0; srcPos < len(src); {
+		b := src[srcPos]
+		srcPos++
+		switch {
+		case b == fsstEscapeCode:
+			if srcPos >= len(src) {
+				break loop
+			}
+			chunk = append(chunk, src[srcPos])
+			srcPos++
+		case t.extended:
+			code := uint16(b)
+			if b == fsstExtendedPrefix {
+				if srcPos >= len(src) {
+					break loop
+				}
+				code = fsstExtendedPrefix + uint16(src[srcPos])
+				srcPos++
+			}
+			if int(code) < len(t.decLenExt) {
+				binary.LittleEndian.PutUint64(tmp[:], t.decSymbolExt[code])
+				chunk = append(chunk, tmp[:t.decLenExt[code]]...)
+			}
+		case t.hasMacros && uint16(b) >= t.macroBase:
+			if idx := uint16(b) - t.macroBase; int(idx) < len(t.macros) {
+				pair := t.macros[idx]
+				for _, c := range pair {
+					binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[c])
+					chunk = append(chunk, tmp[:t.decLen[c]]...)
+				}
+			}
+		case t.backrefWindow > 0 && uint16(b) == t.nSymbols:
+			if srcPos+1 >= len(src) {
+				break loop
+			}
+			dist := int(src[srcPos])
+			length := int(src[srcPos+1])
+			srcPos += 2
+			if dist < 1 || dist > len(chunk) {
+				continue
+			}
+			for range length {
+				chunk = append(chunk, chunk[len(chunk)-dist])
+			}
+		default:
+			binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[b])
+			chunk = append(chunk, tmp[:t.decLen[b]]...)
+		}
+		if len(chunk) >= fsstStreamFlushSize {
+			if err := flush(false); err != nil {
+				return total, err
+			}
+		}
+	}
+	return total, flush(true)
+}
@@ -0,0 +1,76 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDecodeToWriter(t *testing.T) {
+	// Large enough to force several internal flushes.
+	input := bytes.Repeat([]byte("streaming decode test line with words \xff "), 1000)
+	tbl := Train([][]byte{input})
+	comp := tbl.EncodeAll(input)
+
+	var out bytes.Buffer
+	n, err := tbl.DecodeToWriter(&out, comp)
+	if err != nil {
+		t.Fatalf("DecodeToWriter: %v", err)
+	}
+	if n != int64(out.Len()) {
+		t.Fatalf("returned count %d != written %d", n, out.Len())
+	}
+	if !bytes.Equal(out.Bytes(), input) {
+		t.Fatalf("streamed decode mismatch")
+	}
+}
+
+func TestEncodeStream(t *testing.T) {
+	// Spans many read chunks, with 8-byte symbols likely at boundaries.
+	input := bytes.Repeat([]byte("http://example.com/some/long/url/path?q=streaming "), 500)
+	tbl := Train([][]byte{input})
+
+	var comp bytes.Buffer
+	n, err := tbl.EncodeStream(&comp, bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("EncodeStream: %v", err)
+	}
+	if n != int64(comp.Len()) {
+		t.Fatalf("returned count %d != written %d", n, comp.Len())
+	}
+	got := tbl.DecodeAll(comp.Bytes())
+	if !bytes.Equal(got, input) {
+		t.Fatalf("EncodeStream roundtrip mismatch: len(got)=%d len(want)=%d", len(got), len(input))
+	}
+	if comp.Len() >= len(input) {
+		t.Fatalf("expected compression, got %d >= %d", comp.Len(), len(input))
+	}
+}
+
+func TestEncodeStreamEmpty(t *testing.T) {
+	tbl := Train([][]byte{[]byte("anything")})
+	var comp bytes.Buffer
+	n, err := tbl.EncodeStream(&comp, bytes.NewReader(nil))
+	if err != nil || n != 0 {
+		t.Fatalf("empty stream: n=%d err=%v", n, err)
+	}
+}
+
+type failingWriter struct{ n int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n--; w.n < 0 {
+		return 0, errors.New("sink full")
+	}
+	return len(p), nil
+}
+
+func TestDecodeToWriterPropagatesError(t *testing.T) {
+	input := bytes.Repeat([]byte("error propagation "), 2000)
+	tbl := Train([][]byte{input})
+	comp := tbl.EncodeAll(input)
+
+	if _, err := tbl.DecodeToWriter(&failingWriter{n: 1}, comp); err == nil {
+		t.Fatalf("expected writer error to propagate")
+	}
+}
@@ -0,0 +1,52 @@
+package fsst
+
+// fsstReservoirRecords is the default StreamingTrainer reservoir size.
+const fsstReservoirRecords = 1024
+
+// StreamingTrainer trains a table from an unbounded record stream with
+// bounded memory. Observe reservoir-samples whole records (Algorithm R,
+// seeded with the same deterministic RNG as Train's sampler), and Finish
+// trains on the surviving records, so every record seen has an equal chance
+// of shaping the vocabulary no matter how long the firehose runs. Keeping
+// whole records — unlike SampleBuilder, which reservoir-samples 512-byte
+// chunks — means a stream that fits the reservoir entirely is held verbatim
+// and Finish returns exactly what Train on the full data would. Memory is
+// bounded by the reservoir capacity times the record size.
+type StreamingTrainer struct {
+	records  [][]byte // reservoir, each entry an owned copy of one record
+	seen     int      // records observed so far
+	rng      uint64
+	capacity int
+}
+
+// NewStreamingTrainer returns an empty trainer holding up to capacity
+// records; capacity <= 0 selects the default of 1024.
+func NewStreamingTrainer(capacity int) *StreamingTrainer {
+	if capacity <= 0 {
+		capacity = fsstReservoirRecords
+	}
+	return &StreamingTrainer{rng: fsstHash(rngSeed), capacity: capacity}
+}
+
+// Observe offers one record to the reservoir. The record is copied, so the
+// caller may reuse its buffer.
+func (s *StreamingTrainer) Observe(record []byte) {
+	if len(s.records) < s.capacity {
+		s.records = append(s.records, append([]byte(nil), record...))
+	} else {
+		s.rng = fsstHash(s.rng)
+		if j := int(s.rng % uint64(s.seen+1)); j < s.capacity {
+			s.records[j] = append(s.records[j][:0], record...)
+		}
+	}
+	s.seen++
+}
+
+// Seen returns how many records Observe has been offered so far.
+func (s *StreamingTrainer) Seen() int { return s.seen }
+
+// Finish trains a table on the reservoir. The trainer keeps accepting
+// records afterwards; a later Finish sees the updated reservoir.
+func (s *StreamingTrainer) Finish(opts ...TrainOption) *Table {
+	return Train(s.records, opts...)
+}
@@ -0,0 +1,59 @@
+package fsst
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestStreamingTrainerSmallStreamMatchesTrain(t *testing.T) {
+	var inputs [][]byte
+	for i := 0; i < 50; i++ {
+		inputs = append(inputs, []byte(fmt.Sprintf("record %04d from the firehose\n", i)))
+	}
+
+	st := NewStreamingTrainer(100)
+	for _, rec := range inputs {
+		st.Observe(rec)
+	}
+	if st.Seen() != len(inputs) {
+		t.Fatalf("Seen = %d, want %d", st.Seen(), len(inputs))
+	}
+	if !st.Finish().Equal(Train(inputs)) {
+		t.Fatal("small stream: Finish differs from Train on the full data")
+	}
+}
+
+func TestStreamingTrainerBoundedAndDeterministic(t *testing.T) {
+	const capacity = 64
+	a := NewStreamingTrainer(capacity)
+	b := NewStreamingTrainer(capacity)
+	for i := 0; i < 10000; i++ {
+		rec := []byte(fmt.Sprintf("level=info msg=\"stream record\" seq=%d\n", i))
+		a.Observe(rec)
+		b.Observe(rec)
+	}
+	if len(a.records) != capacity {
+		t.Fatalf("reservoir holds %d records, want %d", len(a.records), capacity)
+	}
+	ta, tb := a.Finish(), b.Finish()
+	if !ta.Equal(tb) {
+		t.Fatal("same stream produced different tables")
+	}
+
+	input := []byte("level=info msg=\"stream record\" seq=424242\n")
+	comp := ta.Encode(nil, input)
+	if got := ta.Decode(nil, comp); !bytes.Equal(got, input) {
+		t.Fatal("roundtrip failed on streamed table")
+	}
+}
+
+func TestStreamingTrainerObserveCopies(t *testing.T) {
+	st := NewStreamingTrainer(4)
+	buf := []byte("reused buffer contents")
+	st.Observe(buf)
+	copy(buf, "clobbered!!!!!!!!!!!!!")
+	if !bytes.Equal(st.records[0], []byte("reused buffer contents")) {
+		t.Fatal("Observe retained the caller's buffer instead of copying")
+	}
+}
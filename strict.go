@@ -0,0 +1,62 @@
+package fsst
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEscapeNeeded indicates strict encoding hit a byte with no learned
+// symbol, which would normally be emitted as an escape sequence.
+var ErrEscapeNeeded = errors.New("fsst: input byte not covered by any symbol")
+
+// EncodeStrict compresses input but returns an error wrapping ErrEscapeNeeded
+// instead of emitting escape codes. A table trained on every byte value never
+// escapes, so for pipelines that guarantee full coverage (or minimized tables
+// meant to keep it) a strict failure flags a training gap the moment it
+// appears rather than after data is stored. The error names the first
+// uncovered byte value and its offset in the decoded stream.
+func (t *Table) EncodeStrict(input []byte) ([]byte, error) {
+	comp := t.Encode(nil, input)
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+
+	// Walk the output; any escape means some input byte lacked a symbol. The
+	// decoded offset of the escape is recovered by summing code lengths.
+	decoded := 0
+	for pos := 0; pos < len(comp); {
+		code := uint16(comp[pos])
+		pos++
+		switch {
+		case code == fsstEscapeCode:
+			literal := byte(0)
+			if pos < len(comp) {
+				literal = comp[pos]
+			}
+			return nil, fmt.Errorf("%w: byte 0x%02x at offset %d", ErrEscapeNeeded, literal, decoded)
+		case t.extended:
+			if code == fsstExtendedPrefix && pos < len(comp) {
+				code = fsstExtendedPrefix + uint16(comp[pos])
+				pos++
+			}
+			decoded += int(t.decLenExt[code])
+		case t.hasMacros && code >= t.macroBase:
+			if idx := code - t.macroBase; int(idx) < len(t.macros) {
+				pair := t.macros[idx]
+				decoded += int(t.decLen[pair[0]]) + int(t.decLen[pair[1]])
+			}
+		case t.backrefWindow > 0 && code == t.nSymbols:
+			// Back-reference triple: the copied bytes were already vetted when
+			// first encoded, so only account for their length.
+			if pos+1 < len(comp) {
+				decoded += int(comp[pos+1])
+			}
+			pos += 2
+		default:
+			decoded += int(t.decLen[code])
+		}
+	}
+	return comp, nil
+}
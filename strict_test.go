@@ -0,0 +1,51 @@
+package fsst
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestReadFromStrictExact(t *testing.T) {
+	tbl := Train([][]byte{[]byte("strict readers reject trailing bytes")})
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFromStrict(&buf); err != nil {
+		t.Fatalf("strict read of exact blob: %v", err)
+	}
+}
+
+func TestReadFromStrictTrailingBytes(t *testing.T) {
+	tbl := Train([][]byte{[]byte("strict readers reject trailing bytes")})
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf.WriteByte(0) // an under-claiming header would leave bytes like this
+	var tbl2 Table
+	if _, err := tbl2.ReadFromStrict(&buf); !errors.Is(err, ErrTrailingData) {
+		t.Fatalf("err = %v, want ErrTrailingData", err)
+	}
+}
+
+func TestReadFromStrictTruncated(t *testing.T) {
+	tbl := Train([][]byte{[]byte("strict readers reject trailing bytes")})
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// An over-claiming header looks like a truncated stream: drop the last
+	// symbol bytes so ReadFull comes up short.
+	// short. Depending on where the cut lands the failing ReadFull reports
+	// either flavor of EOF.
+	short := buf.Bytes()[:buf.Len()-3]
+	var tbl2 Table
+	_, err := tbl2.ReadFromStrict(bytes.NewReader(short))
+	if !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		t.Fatalf("err = %v, want an EOF error", err)
+	}
+}
@@ -7,10 +7,10 @@ import (
 // Core constants for FSST compression algorithm
 const (
 	fsstLenBits  = 12
-	fsstCodeBits = 9
+	fsstCodeBits = 10
 	fsstCodeBase = 256               // First code for learned symbols (0-255 are escapes)
-	fsstCodeMax  = 1 << fsstCodeBits // 512
-	fsstCodeMask = fsstCodeMax - 1   // 0x1FF
+	fsstCodeMax  = 1 << fsstCodeBits // 1024
+	fsstCodeMask = fsstCodeMax - 1   // 0x3FF
 
 	fsstHashLog2Size = 11
 	fsstHashTabSize  = 1 << fsstHashLog2Size // 2048 entries
@@ -18,13 +18,19 @@ const (
 	fsstShift        = 15
 
 	// fsstICLFree marks unused hash table slots.
-	// Layout: length=15 (impossible) at bits 28-31, code=0x1FF at bits 16-27
+	// Layout: length=15 (impossible) at bits 28-31, code=0x3FF at bits 16-27
 	fsstICLFree = (uint64(15) << 28) | (uint64(fsstCodeMask) << 16)
 
 	fsstEscapeCode = 255 // Code 255 indicates next byte is literal
 	fsstMaxSymbols = 255 // Maximum number of learned symbols (codes 0-254)
 	fsstChunkSize  = 511 // Process input in 511-byte chunks for cache efficiency
 
+	// Extended code space (WithExtendedCodes): codes [0..fsstExtendedPrefix)
+	// are emitted as single bytes, codes [fsstExtendedPrefix..fsstMaxSymbolsExtended)
+	// as the prefix byte followed by (code - fsstExtendedPrefix).
+	fsstExtendedPrefix     = 254                        // Byte 254 prefixes a two-byte extended code
+	fsstMaxSymbolsExtended = fsstExtendedPrefix + 256   // 510 learned symbols in extended mode
+
 	// Training subsampling mask (0-127 range for deterministic sampling)
 	fsstSampleMask = 127
 
@@ -41,6 +47,11 @@ const (
 	fsstMask24 = 0xFFFFFF // 24-bit mask (3 bytes)
 )
 
+// fsstUnalignedLoad reads 8 bytes as a little-endian word. Going through
+// encoding/binary pins the byte order and tolerates any alignment: the
+// compiler lowers this to a single load on little-endian targets and a
+// byte-swapped load elsewhere, so encode output and symbol values are
+// identical on every architecture.
 func fsstUnalignedLoad(b []byte) uint64 { return binary.LittleEndian.Uint64(b) }
 func fsstHash(w uint64) uint64          { x := w * fsstHashPrime; return x ^ (x >> fsstShift) }
 
@@ -94,6 +105,40 @@ func (s symbol) first() byte         { return byte(s.val & fsstMask8) }
 func (s symbol) first2() uint16      { return uint16(s.val & fsstMask16) }
 func (s symbol) hash() uint64        { return fsstHash(s.val & fsstMask24) }
 
+// symbolRuneAligned reports whether a symbol's bytes neither start on a
+// UTF-8 continuation byte nor end with a truncated multi-byte sequence.
+// Single bytes count as aligned: they cannot straddle anything on their own.
+// Invalid sequences (a stray continuation byte mid-symbol) count as
+// unaligned.
+func symbolRuneAligned(s symbol) bool {
+	length := int(s.length())
+	if length <= 1 {
+		return true
+	}
+	pos := 0
+	for pos < length {
+		b := byte(s.val >> (8 * pos))
+		var runeLen int
+		switch {
+		case b&0x80 == 0:
+			runeLen = 1
+		case b&0xE0 == 0xC0:
+			runeLen = 2
+		case b&0xF0 == 0xE0:
+			runeLen = 3
+		case b&0xF8 == 0xF0:
+			runeLen = 4
+		default:
+			return false // continuation byte where a rune should start
+		}
+		if pos+runeLen > length {
+			return false // trailing rune is cut off
+		}
+		pos += runeLen
+	}
+	return true
+}
+
 func fsstConcat(a, b symbol) symbol {
 	lengthA := a.length()
 	lengthB := b.length()
@@ -5,12 +5,20 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
+	"sync"
 	"unsafe"
 )
 
 // Table holds a trained symbol table for compression and decompression.
 // A Table is created via Train and can encode or decode byte slices.
 // After training, a Table can be serialized with WriteTo and restored with ReadFrom.
+//
+// Concurrency: a Table is not safe for concurrent use while its lazy lookup
+// structures are being built, and encoding methods reuse per-table scratch
+// buffers, so concurrent Encode calls on one Table are never safe — give each
+// encoding goroutine its own Table (Clone is cheap). Decoding only reads the
+// decoder arrays, so once they exist — after one Decode call, or an explicit
+// WarmDecoder — any number of goroutines may Decode concurrently.
 type Table struct {
 	// Symbol lookup structures (for encoding)
 	shortCodes [65536]uint16           // 2-byte prefix -> [length|code], fast unique 2B path
@@ -18,6 +26,11 @@ type Table struct {
 	symbols    [fsstCodeMax]symbol     // canonical code -> symbol (value+length)
 	hashTab    [fsstHashTabSize]symbol // direct-mapped 3–8B symbols keyed by first 3 bytes
 
+	// trainHash, when non-nil, replaces hashTab for 3-8 byte lookups during
+	// training only (WithTrainingHashSize). It is dropped after finalize so
+	// the serialized and encoding table stays standard.
+	trainHash []symbol
+
 	// Length-specific hash tables for faster encoding (no dynamic masking)
 	hashTab3 [fsstHashTabSize]symbol // 3-byte symbols only
 	hashTab4 [fsstHashTabSize]symbol // 4-byte symbols only
@@ -27,9 +40,38 @@ type Table struct {
 	hashTab8 [fsstHashTabSize]symbol // 8-byte symbols only
 
 	// Symbol metadata
-	nSymbols  uint16    // number of learned symbols (0..255)
-	suffixLim uint16    // end of unique 2B region [0..suffixLim)
-	lenHisto  [8]uint16 // histogram of lengths 1..8 at indices 0..7
+	nSymbols   uint16    // number of learned symbols (0..255, or 0..510 when extended)
+	suffixLim  uint16    // end of unique 2B region [0..suffixLim)
+	lenHisto   [8]uint16 // histogram of lengths 1..8 at indices 0..7
+	extended   bool      // extended code space (up to 510 symbols, two-byte codes)
+	uniformLen uint8     // shared symbol length when all symbols match, else 0
+	asciiOnly  bool      // training saw no high-bit bytes; enables ASCII encode loop
+
+	// Macro codes (WithMacros): spare codes in [macroBase..255) that expand
+	// to a pair of regular codes, lifting the 8-byte symbol length limit.
+	hasMacros   bool
+	macroBase   uint16
+	macros      [][2]uint16
+	macroLookup map[[2]uint16]uint16 // code pair -> macro code (encoder side)
+
+	// Back-references (WithBackrefs): repeats of recently decoded bytes are
+	// emitted as marker+distance+length instead of symbol codes. 0 = off.
+	backrefWindow uint8
+
+	// Order-preserving mode (TrainOrderPreserving): codes are assigned in
+	// lexicographic order over a prefix-free symbol trie so compressed
+	// outputs sort like their plaintexts. Bytes >= ordEscMin are escaped.
+	ordered   bool
+	ordEscMin uint8          // first escaped byte value (1..255)
+	ordLeaves [][]byte       // kept symbols in lexicographic order (serialized form)
+	ordRoot   *[256]*ordNode // encode trie, built by buildOrdered
+
+	// Frequency-ordered wire codes (AssignCodesByFrequency): the encoded
+	// stream is rewritten so the most-used symbol goes out as code 0. The
+	// canonical layout above stays untouched; freqRemap maps canonical code
+	// -> wire code and freqInv the reverse. nil = identity.
+	freqRemap []uint8
+	freqInv   []uint8
 
 	// Encoder state (lazy-initialized on first Encode)
 	// accelReady: true when shortCodes/byteCodes/hashTab are populated for encoding.
@@ -40,6 +82,22 @@ type Table struct {
 	noSuffixOpt bool   // enable 2-byte fast path without suffix check
 	avoidBranch bool   // prefer branchless emission in encodeChunk
 	encBuf      []byte // scratch chunk buffer used by Encode
+	lenBuf      []byte // scratch output buffer used by EncodedLen
+	frameBuf    []byte // scratch payload buffer used by EncodeFramed
+
+	// variantForced: set by SetVariant so the lazy chooseVariant call does not
+	// overwrite a caller-chosen strategy.
+	variantForced bool
+
+	// chunkSize: encode chunk length override set via SetChunkSize; 0 means
+	// the fsstChunkSize default. Performance tuning only — chunk boundaries
+	// never change the encoded output.
+	chunkSize int
+
+	// observer, when non-nil, receives OnEncodeChunk callbacks from the plain
+	// Encode path (SetObserver, WithObserver). Runtime-only: not serialized
+	// and not part of Equal or Fingerprint.
+	observer Observer
 
 	// Decoder state (lazy-initialized on first Decode)
 	// decLen/decSymbol: flattened arrays for fast decoding (indexed by code).
@@ -48,14 +106,41 @@ type Table struct {
 	decLen    [255]byte   // code → symbol length
 	decSymbol [255]uint64 // code → symbol value (little-endian)
 	decReady  bool        // decoder lookup tables are ready
+
+	// Extended-mode decoder arrays (allocated lazily, nSymbols entries)
+	decLenExt    []byte
+	decSymbolExt []uint64
 }
 
 // Version is the FSST format version (publication date: February 18, 2019).
 const fsstVersion uint64 = 20190218
 
+// Serialization format flags stored in the low byte of the version word.
+const (
+	fsstFormatPlain      = 1 // raw symbol bytes (WriteTo/ReadFrom)
+	fsstFormatCompressed = 2 // front-coded symbol bytes (WriteToCompressed/ReadFromCompressed)
+	fsstFormatExtended   = 3 // extended code space, 16-bit header fields
+	fsstFormatMacros     = 4 // plain layout plus macro-pair trailer
+	fsstFormatBackrefs   = 5 // plain layout plus back-reference window byte
+	fsstFormatOrdered    = 6 // order-preserving leaves; codes rebuilt on read
+	fsstFormatFreqCodes  = 7 // plain layout plus frequency code remap trailer
+)
+
 // ErrBadVersion indicates the serialized table version is not supported.
 var ErrBadVersion = errors.New("fsst: unsupported table version")
 
+// ErrBadFormat indicates the serialized table format flag or symbol block is
+// not valid for the method used to read it.
+var ErrBadFormat = errors.New("fsst: invalid serialized table format")
+
+// ErrTrailingData indicates a strict read found unconsumed bytes after a
+// complete serialized table.
+var ErrTrailingData = errors.New("fsst: trailing data after serialized table")
+
+// ErrBadChunkSize is returned by SetChunkSize for lengths the encode loop
+// cannot process safely.
+var ErrBadChunkSize = errors.New("fsst: chunk size out of range")
+
 // newTable initializes a new empty table with defaults.
 func newTable() *Table {
 	t := &Table{}
@@ -102,27 +187,39 @@ func (t *Table) clearSymbols() {
 			first2Bytes := t.symbols[i].first2()
 			t.shortCodes[first2Bytes] = packCodeLength(uint16(first2Bytes&fsstMask8), 1)
 		default:
-			hashIndex := t.symbols[i].hash() & (fsstHashTabSize - 1)
-			t.hashTab[hashIndex].val = 0
-			t.hashTab[hashIndex].icl = fsstICLFree
+			tab, idxMask := t.trainHashTab()
+			hashIndex := t.symbols[i].hash() & idxMask
+			tab[hashIndex].val = 0
+			tab[hashIndex].icl = fsstICLFree
 		}
 	}
 	t.nSymbols = 0
 }
 
+// trainHashTab returns the direct-mapped hash table used for 3+ byte symbol
+// lookups plus its index mask: the enlarged trainHash while training with
+// WithTrainingHashSize, otherwise the standard fixed-size hashTab.
+func (t *Table) trainHashTab() ([]symbol, uint64) {
+	if t.trainHash != nil {
+		return t.trainHash, uint64(len(t.trainHash) - 1)
+	}
+	return t.hashTab[:], fsstHashTabSize - 1
+}
+
 // hashInsert inserts a 3+ byte symbol into the direct-mapped hash table.
 // It stores the symbol with masked value (ignore high bytes) and returns
 // false if the target slot is already occupied.
 func (t *Table) hashInsert(sym symbol) bool {
-	hashIndex := sym.hash() & (fsstHashTabSize - 1)
-	taken := t.hashTab[hashIndex].icl < fsstICLFree
+	tab, idxMask := t.trainHashTab()
+	hashIndex := sym.hash() & idxMask
+	taken := tab[hashIndex].icl < fsstICLFree
 	if taken {
 		return false
 	}
-	t.hashTab[hashIndex].icl = sym.icl
+	tab[hashIndex].icl = sym.icl
 	// mask high ignored bits before storing
 	mask := ^uint64(0) >> sym.ignoredBits()
-	t.hashTab[hashIndex].val = sym.val & mask
+	tab[hashIndex].val = sym.val & mask
 	return true
 }
 
@@ -204,8 +301,9 @@ func (t *Table) addSymbol(sym symbol) bool {
 
 // findLongestSymbol decides the longest match at cur represented as a temporary symbol.
 func (t *Table) findLongestSymbol(sym symbol) uint16 {
-	hashIndex := sym.hash() & (fsstHashTabSize - 1)
-	hashEntry := t.hashTab[hashIndex]
+	tab, idxMask := t.trainHashTab()
+	hashIndex := sym.hash() & idxMask
+	hashEntry := tab[hashIndex]
 	if hashEntry.icl <= sym.icl {
 		mask := ^uint64(0) >> uint(hashEntry.ignoredBits())
 		if hashEntry.val == (sym.val & mask) {
@@ -237,19 +335,24 @@ func (t *Table) findLongestSymbol(sym symbol) uint16 {
 // Effects: updates code assignments in symbols[], sets suffixLim accordingly,
 // preserves lengths, and leaves rebuilding of fast lookup tables to rebuildIndices.
 func (t *Table) finalize() {
-	// Precondition: nSymbols <= 255
-	newCode := make([]uint8, 256)
-	var codeStart [8]uint8 // Starting code for each length group (1-8 bytes)
-	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+	// Precondition: nSymbols <= 255 (510 when extended). Enforced hard: a
+	// learned symbol at code fsstEscapeCode would make Decode misread real
+	// data as escapes, so this can never be allowed to slip through.
+	if limit := uint16(fsstMaxSymbols); !t.extended && t.nSymbols > limit {
+		panic("fsst: internal error: symbol count would occupy the escape code")
+	}
+	newCode := make([]uint16, fsstMaxSymbolsExtended)
+	var codeStart [8]uint16 // Starting code for each length group (1-8 bytes)
+	byteLim := t.nSymbols - t.lenHisto[0]
 
 	// Initialize code ranges: 1-byte symbols get [byteLim, nSymbols)
 	codeStart[0] = byteLim
 	codeStart[1] = 0 // 2-byte symbols start at 0 (will be partitioned)
 	for i := 1; i < 7; i++ {
-		codeStart[i+1] = codeStart[i] + uint8(t.lenHisto[i])
+		codeStart[i+1] = codeStart[i] + t.lenHisto[i]
 	}
 
-	t.suffixLim = uint16(codeStart[1])
+	t.suffixLim = codeStart[1]
 	t.symbols[newCode[0]] = t.symbols[256]
 
 	// Assign new codes, partitioning 2-byte symbols by prefix uniqueness
@@ -275,12 +378,12 @@ func (t *Table) finalize() {
 
 			if !hasConflict {
 				// Unique prefix: assign to fast-path range [0..suffixLim)
-				newCode[i] = uint8(t.suffixLim)
+				newCode[i] = t.suffixLim
 				t.suffixLim++
 			} else {
 				// Conflicting prefix: assign to slow-path range [suffixLim..codeStart[2])
 				conflictingTwoByteCode--
-				newCode[i] = uint8(conflictingTwoByteCode)
+				newCode[i] = uint16(conflictingTwoByteCode)
 			}
 		} else {
 			// Non-2-byte symbols: assign sequentially within length group
@@ -292,6 +395,24 @@ func (t *Table) finalize() {
 		sym.setCodeLen(uint32(newCode[i]), length)
 		t.symbols[int(newCode[i])] = sym
 	}
+	t.uniformLen = computeUniformLen(t.lenHisto)
+}
+
+// computeUniformLen returns the shared symbol length when every learned
+// symbol has the same one, or 0 when lengths vary (or no symbols exist).
+// A uniform table decodes with a constant stride, skipping length lookups.
+func computeUniformLen(lenHisto [8]uint16) uint8 {
+	uniform := uint8(0)
+	for i, cnt := range lenHisto {
+		if cnt == 0 {
+			continue
+		}
+		if uniform != 0 {
+			return 0
+		}
+		uniform = uint8(i + 1)
+	}
+	return uniform
 }
 
 // WriteTo serializes the finalized Table to w using the compact FSST header format.
@@ -300,11 +421,77 @@ func (t *Table) finalize() {
 // - 8 bytes lenHisto (u8)
 // - concatenated symbol bytes for codes [0..nSymbols) in length-group order
 func (t *Table) WriteTo(w io.Writer) (int64, error) {
-	// pack version
+	if t.extended {
+		return t.writeToExtended(w)
+	}
+	if t.hasMacros {
+		return t.writeToMacros(w)
+	}
+	if t.backrefWindow > 0 {
+		return t.writeToBackrefs(w)
+	}
+	if t.ordered {
+		return t.writeToOrdered(w)
+	}
+	if t.freqRemap != nil {
+		n, err := t.writeToPlain(w, fsstFormatFreqCodes)
+		if err != nil {
+			return n, err
+		}
+		nn, err := w.Write(t.freqRemap)
+		return n + int64(nn), err
+	}
+	return t.writeToPlain(w, fsstFormatPlain)
+}
+
+// SerializedLen returns the exact number of bytes WriteTo will produce for
+// the table in its current mode. Catalogs packing many tables back-to-back in
+// one file can compute each table's offset up front instead of re-deriving it
+// from headers after the fact.
+func (t *Table) SerializedLen() int {
+	symbolBytes := 0
+	for i := range t.lenHisto {
+		symbolBytes += (i + 1) * int(t.lenHisto[i])
+	}
+	switch {
+	case t.extended:
+		// version + suffixLim/nSymbols + 16-bit lenHisto
+		return 8 + 4 + 16 + symbolBytes
+	case t.hasMacros:
+		// plain layout + macro trailer (count byte + two codes per macro)
+		return 8 + 8 + symbolBytes + 1 + 4*len(t.macros)
+	case t.backrefWindow > 0:
+		// plain layout + one window byte
+		return 8 + 8 + symbolBytes + 1
+	case t.ordered:
+		// version word + per-leaf length byte and bytes; gap and single
+		// codes are rebuilt from the leaves on read
+		n := 8
+		for _, leaf := range t.ordLeaves {
+			n += 1 + len(leaf)
+		}
+		return n
+	case t.freqRemap != nil:
+		// plain layout + remap trailer (one wire code per symbol)
+		return 8 + 8 + symbolBytes + int(t.nSymbols)
+	default:
+		// version + 8-bit lenHisto
+		return 8 + 8 + symbolBytes
+	}
+}
+
+// writeToPlain writes the compact header-plus-symbols layout with the given
+// format flag in the version word's low byte.
+func (t *Table) writeToPlain(w io.Writer, flag byte) (int64, error) {
+	// pack version; bit 24 carries the ASCII-only hint (ignored by older
+	// readers, which mask suffixLim to 8 bits)
 	ver := (fsstVersion << 32) |
 		(uint64(t.suffixLim) << 16) |
 		(uint64(t.nSymbols) << 8) |
-		1
+		uint64(flag)
+	if t.asciiOnly {
+		ver |= 1 << 24
+	}
 	var (
 		n    int64
 		buf8 [8]byte
@@ -350,6 +537,63 @@ func (t *Table) WriteTo(w io.Writer) (int64, error) {
 	return n, nil
 }
 
+// writeToExtended serializes an extended-mode Table. suffixLim, nSymbols and
+// lenHisto can exceed a byte in extended mode, so they are written as 16-bit
+// fields after the version word instead of packed into it.
+func (t *Table) writeToExtended(w io.Writer) (int64, error) {
+	ver := (fsstVersion << 32) | fsstFormatExtended
+	var (
+		n    int64
+		buf8 [8]byte
+	)
+	binary.LittleEndian.PutUint64(buf8[:], ver)
+	if nn, err := w.Write(buf8[:]); err != nil {
+		return n, err
+	} else {
+		n += int64(nn)
+	}
+	binary.LittleEndian.PutUint16(buf8[:2], t.suffixLim)
+	binary.LittleEndian.PutUint16(buf8[2:4], t.nSymbols)
+	if nn, err := w.Write(buf8[:4]); err != nil {
+		return n, err
+	} else {
+		n += int64(nn)
+	}
+	// lenHisto derived from symbols, 16-bit entries
+	var (
+		lh   [16]byte
+		hist [8]uint16
+	)
+	for i := range int(t.nSymbols) {
+		length := t.symbols[i].length()
+		if length >= 1 && length <= 8 {
+			hist[length-1]++
+		}
+	}
+	for i := range 8 {
+		binary.LittleEndian.PutUint16(lh[2*i:], hist[i])
+	}
+	if nn, err := w.Write(lh[:]); err != nil {
+		return n, err
+	} else {
+		n += int64(nn)
+	}
+	// symbol bytes
+	for i := range int(t.nSymbols) {
+		sym := t.symbols[i]
+		symbolLength := int(sym.length())
+		for byteIdx := range symbolLength {
+			buf8[byteIdx] = byte(sym.val >> (8 * byteIdx))
+		}
+		if nn, err := w.Write(buf8[:symbolLength]); err != nil {
+			return n, err
+		} else {
+			n += int64(nn)
+		}
+	}
+	return n, nil
+}
+
 // ReadFrom deserializes a Table from r using the compact FSST header format.
 func (t *Table) ReadFrom(r io.Reader) (int64, error) {
 	*t = *newTable() // reset
@@ -365,8 +609,24 @@ func (t *Table) ReadFrom(r io.Reader) (int64, error) {
 	if ver>>32 != fsstVersion {
 		return n, ErrBadVersion
 	}
+	if ver&fsstMask8 == fsstFormatExtended {
+		return t.readFromExtended(r, n)
+	}
+	if ver&fsstMask8 == fsstFormatOrdered {
+		return t.readFromOrdered(r, n, ver)
+	}
+	switch ver & fsstMask8 {
+	case fsstFormatPlain, fsstFormatMacros, fsstFormatBackrefs, fsstFormatFreqCodes:
+		// plain symbol layout, possibly with a trailer — parsed below
+	default:
+		// Anything else — the compressed format (which has its own reader)
+		// or an unknown flag — must not fall through to the plain parse and
+		// build a silently corrupt table.
+		return n, ErrBadFormat
+	}
 	t.suffixLim = uint16((ver >> 16) & fsstMask8)
 	t.nSymbols = uint16((ver >> 8) & fsstMask8)
+	t.asciiOnly = ver&(1<<24) != 0
 	// endian marker ignored (lowest byte)
 	var lh [8]byte
 	if _, err := io.ReadFull(r, lh[:]); err != nil {
@@ -376,6 +636,16 @@ func (t *Table) ReadFrom(r io.Reader) (int64, error) {
 	for i := range 8 {
 		t.lenHisto[i] = uint16(lh[i])
 	}
+	// The symbol count must fit below the escape code, and the length
+	// histogram must account for exactly nSymbols: a mismatch would assign a
+	// symbol to code 255 (or beyond) and corrupt escape handling on decode.
+	var histoSum int
+	for i := range 8 {
+		histoSum += int(t.lenHisto[i])
+	}
+	if int(t.nSymbols) > fsstMaxSymbols || histoSum != int(t.nSymbols) {
+		return n, ErrBadFormat
+	}
 	// read symbol bytes into symbols[0..nSymbols)
 	// Build code->length schedule from lenHisto
 	lens := make([]uint8, t.nSymbols)
@@ -412,118 +682,449 @@ func (t *Table) ReadFrom(r io.Reader) (int64, error) {
 		t.symbols[i] = sym
 	}
 	t.accelReady = false
+	t.uniformLen = computeUniformLen(t.lenHisto)
+	if ver&fsstMask8 == fsstFormatMacros {
+		return t.readMacroTrailer(r, n)
+	}
+	if ver&fsstMask8 == fsstFormatBackrefs {
+		return t.readBackrefTrailer(r, n)
+	}
+	if ver&fsstMask8 == fsstFormatFreqCodes {
+		return t.readFreqTrailer(r, n)
+	}
 	return n, nil
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-func (t *Table) MarshalBinary() ([]byte, error) {
-	var buf bytes.Buffer
-	if _, err := t.WriteTo(&buf); err != nil {
-		return nil, err
+// ReadFromStrict deserializes a Table like ReadFrom, but additionally
+// requires that r is exhausted once the table is fully read: a header that
+// under-claims its symbol count would otherwise leave trailing bytes silently
+// ignored. Use it when r holds exactly one serialized table, e.g. a stored
+// blob, to detect format drift. Returns ErrTrailingData if bytes remain.
+func (t *Table) ReadFromStrict(r io.Reader) (int64, error) {
+	n, err := t.ReadFrom(r)
+	if err != nil {
+		return n, err
 	}
-	return buf.Bytes(), nil
-}
-
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (t *Table) UnmarshalBinary(data []byte) error {
-	_, err := t.ReadFrom(bytes.NewReader(data))
-	return err
+	var extra [1]byte
+	if nn, err := io.ReadFull(r, extra[:]); nn > 0 || err != io.EOF {
+		return n, ErrTrailingData
+	}
+	return n, nil
 }
 
-// rebuildIndices reconstructs byteCodes, shortCodes, and hashTab from the
-// finalized symbols. It preserves existing code assignments (already set in
-// symbols[i]) and only rebuilds the derived lookup structures. Safe to call
-// multiple times; it is a no-op if accelReady is already true.
-func (t *Table) rebuildIndices() {
-	if t.accelReady {
-		return
+// readFromExtended finishes deserializing after the version word of a table
+// written by writeToExtended. The newTable reset already happened in ReadFrom.
+func (t *Table) readFromExtended(r io.Reader, n int64) (int64, error) {
+	t.extended = true
+	var hdr [16]byte
+	if _, err := io.ReadFull(r, hdr[:4]); err != nil {
+		return n, err
 	}
-	// 1) Reset to defaults
-	// byteCodes default to ESCAPE (fsstCodeMask) with len=1 marker
-	for i := range 256 {
-		t.byteCodes[i] = packCodeLength(fsstCodeMask, 1)
+	n += 4
+	t.suffixLim = binary.LittleEndian.Uint16(hdr[:2])
+	t.nSymbols = binary.LittleEndian.Uint16(hdr[2:4])
+	if int(t.nSymbols) > fsstMaxSymbolsExtended {
+		return n, ErrBadFormat
 	}
-	// Clear hash tables
-	empty := symbol{}
-	empty.val = 0
-	empty.icl = fsstICLFree
-	for i := range fsstHashTabSize {
-		t.hashTab[i] = empty
-		t.hashTab3[i] = empty
-		t.hashTab4[i] = empty
-		t.hashTab5[i] = empty
-		t.hashTab6[i] = empty
-		t.hashTab7[i] = empty
-		t.hashTab8[i] = empty
+	if _, err := io.ReadFull(r, hdr[:16]); err != nil {
+		return n, err
 	}
-
-	// 2) Apply single-byte symbols to byteCodes
+	n += 16
+	for i := range 8 {
+		t.lenHisto[i] = binary.LittleEndian.Uint16(hdr[2*i:])
+	}
+	// Build code->length schedule from lenHisto (lengths 2..8, then 1)
+	lens := make([]uint8, t.nSymbols)
+	pos := 0
+	for l := 2; l <= 8; l++ {
+		cnt := int(t.lenHisto[l-1])
+		for range cnt {
+			if pos >= len(lens) {
+				return n, ErrBadFormat
+			}
+			lens[pos] = uint8(l)
+			pos++
+		}
+	}
+	cnt1 := int(t.lenHisto[0])
+	for j := 0; j < cnt1; j++ {
+		if pos >= len(lens) {
+			return n, ErrBadFormat
+		}
+		lens[pos] = 1
+		pos++
+	}
+	// read symbols accordingly
 	for i := range int(t.nSymbols) {
-		sym := t.symbols[i]
-		length := sym.length()
-		if length == 1 {
-			firstByte := sym.first()
-			t.byteCodes[firstByte] = packCodeLength(uint16(i), 1)
+		symbolLength := int(lens[i])
+		var b8 [8]byte
+		if _, err := io.ReadFull(r, b8[:symbolLength]); err != nil {
+			return n, err
+		}
+		n += int64(symbolLength)
+		var symbolValue uint64
+		for byteIdx := range symbolLength {
+			symbolValue |= uint64(b8[byteIdx]) << (8 * byteIdx)
 		}
+		sym := symbol{val: symbolValue}
+		sym.setCodeLen(uint32(i), uint32(symbolLength))
+		t.symbols[i] = sym
 	}
+	t.accelReady = false
+	return n, nil
+}
 
-	// 3) Initialize shortCodes to mirror byteCodes of the first byte
-	for i := range 65536 {
-		first := i & fsstMask8
-		t.shortCodes[i] = t.byteCodes[first]
+// WriteToCompressed serializes the Table like WriteTo but front-codes the
+// symbol block: each symbol is stored as one byte giving the number of leading
+// bytes shared with the previous symbol, followed by the remaining suffix
+// bytes. Finalization groups symbols by length, so adjacent symbols often
+// share prefixes and small tables shrink measurably. The format is marked by
+// a distinct flag byte in the version word, so WriteTo output stays readable
+// by ReadFrom and vice versa is rejected.
+func (t *Table) WriteToCompressed(w io.Writer) (int64, error) {
+	if t.extended || t.hasMacros || t.backrefWindow > 0 || t.ordered || t.freqRemap != nil {
+		// Extended headers, macro trailers, backref windows, and reassigned
+		// code layouts don't fit this format
+		return 0, ErrBadFormat
 	}
-
-	// 4) Apply two-byte symbols to shortCodes
+	// pack version with compressed-format marker
+	ver := (fsstVersion << 32) |
+		(uint64(t.suffixLim) << 16) |
+		(uint64(t.nSymbols) << 8) |
+		fsstFormatCompressed
+	var (
+		n    int64
+		buf8 [8]byte
+	)
+	binary.LittleEndian.PutUint64(buf8[:], ver)
+	if nn, err := w.Write(buf8[:]); err != nil {
+		return n, err
+	} else {
+		n += int64(nn)
+	}
+	// Write lenHisto derived from symbols to avoid relying on stored state
+	var (
+		lh   [8]byte
+		hist [8]uint16
+	)
 	for i := range int(t.nSymbols) {
-		sym := t.symbols[i]
-		if sym.length() == 2 {
-			t.shortCodes[sym.first2()] = packCodeLength(uint16(i), 2)
+		length := t.symbols[i].length()
+		if length >= 1 && length <= 8 {
+			hist[length-1]++
 		}
 	}
-
-	// 5) Insert 3+ byte symbols into hash tables
+	for i := range 8 {
+		lh[i] = byte(hist[i])
+	}
+	if nn, err := w.Write(lh[:]); err != nil {
+		return n, err
+	} else {
+		n += int64(nn)
+	}
+	// Front-coded symbol block
+	var prev [8]byte
+	prevLen := 0
+	var out [9]byte
 	for i := range int(t.nSymbols) {
 		sym := t.symbols[i]
-		length := sym.length()
-		switch length {
-		case 3:
-			_ = t.hashInsert(sym) // For training
-			t.hashInsert3(sym)
-		case 4:
-			_ = t.hashInsert(sym) // For training
-			t.hashInsert4(sym)
-		case 5:
-			_ = t.hashInsert(sym) // For training
-			t.hashInsert5(sym)
-		case 6:
-			_ = t.hashInsert(sym) // For training
-			t.hashInsert6(sym)
-		case 7:
-			_ = t.hashInsert(sym) // For training
-			t.hashInsert7(sym)
-		case 8:
-			_ = t.hashInsert(sym) // For training
-			t.hashInsert8(sym)
+		symbolLength := int(sym.length())
+		var cur [8]byte
+		for byteIdx := range symbolLength {
+			cur[byteIdx] = byte(sym.val >> (8 * byteIdx))
 		}
-	}
-
-	t.accelReady = true
-}
-
-// Encode compresses input, optionally reusing buf for output.
-// buf can be nil or undersized; it will be grown as needed.
-// Returns the compressed data (may have different backing array than buf).
-func (t *Table) Encode(buf, input []byte) []byte {
-	// Lazy-initialize encoder structures
-	if t.encBuf == nil {
-		if !t.accelReady {
-			t.rebuildIndices()
+		shared := 0
+		for shared < prevLen && shared < symbolLength && cur[shared] == prev[shared] {
+			shared++
 		}
-		t.noSuffixOpt, t.avoidBranch = chooseVariant(t)
-		t.encBuf = make([]byte, fsstChunkSize+fsstChunkPadding)
+		out[0] = byte(shared)
+		copy(out[1:], cur[shared:symbolLength])
+		if nn, err := w.Write(out[:1+symbolLength-shared]); err != nil {
+			return n, err
+		} else {
+			n += int64(nn)
+		}
+		prev = cur
+		prevLen = symbolLength
 	}
+	return n, nil
+}
 
-	if buf == nil {
+// ReadFromCompressed deserializes a Table written by WriteToCompressed.
+func (t *Table) ReadFromCompressed(r io.Reader) (int64, error) {
+	*t = *newTable() // reset
+	var (
+		n   int64
+		hdr [8]byte
+	)
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return n, err
+	}
+	n += 8
+	ver := binary.LittleEndian.Uint64(hdr[:])
+	if ver>>32 != fsstVersion {
+		return n, ErrBadVersion
+	}
+	if ver&fsstMask8 != fsstFormatCompressed {
+		return n, ErrBadFormat
+	}
+	t.suffixLim = uint16((ver >> 16) & fsstMask8)
+	t.nSymbols = uint16((ver >> 8) & fsstMask8)
+	var lh [8]byte
+	if _, err := io.ReadFull(r, lh[:]); err != nil {
+		return n, err
+	}
+	n += 8
+	for i := range 8 {
+		t.lenHisto[i] = uint16(lh[i])
+	}
+	// Same guard as ReadFrom: the histogram must account for exactly
+	// nSymbols, or the schedule below would overrun (and a symbol would land
+	// on the escape code).
+	var histoSum int
+	for i := range 8 {
+		histoSum += int(t.lenHisto[i])
+	}
+	if int(t.nSymbols) > fsstMaxSymbols || histoSum != int(t.nSymbols) {
+		return n, ErrBadFormat
+	}
+	// Build code->length schedule from lenHisto (lengths 2..8, then 1)
+	lens := make([]uint8, t.nSymbols)
+	pos := 0
+	for l := 2; l <= 8; l++ {
+		cnt := int(t.lenHisto[l-1])
+		for range cnt {
+			lens[pos] = uint8(l)
+			pos++
+		}
+	}
+	cnt1 := int(t.lenHisto[0])
+	for j := 0; j < cnt1; j++ {
+		lens[pos] = 1
+		pos++
+	}
+	// Read front-coded symbols
+	var prev [8]byte
+	prevLen := 0
+	for i := range int(t.nSymbols) {
+		symbolLength := int(lens[i])
+		var sharedByte [1]byte
+		if _, err := io.ReadFull(r, sharedByte[:]); err != nil {
+			return n, err
+		}
+		n++
+		shared := int(sharedByte[0])
+		if shared > prevLen || shared > symbolLength {
+			return n, ErrBadFormat
+		}
+		var cur [8]byte
+		copy(cur[:shared], prev[:shared])
+		if _, err := io.ReadFull(r, cur[shared:symbolLength]); err != nil {
+			return n, err
+		}
+		n += int64(symbolLength - shared)
+		var symbolValue uint64
+		for byteIdx := range symbolLength {
+			symbolValue |= uint64(cur[byteIdx]) << (8 * byteIdx)
+		}
+		sym := symbol{val: symbolValue}
+		sym.setCodeLen(uint32(i), uint32(symbolLength))
+		t.symbols[i] = sym
+		prev = cur
+		prevLen = symbolLength
+	}
+	t.accelReady = false
+	t.uniformLen = computeUniformLen(t.lenHisto)
+	return n, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (t *Table) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := t.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender: it appends the serialized
+// table to dst and returns the extended slice, producing bytes identical to
+// MarshalBinary. SerializedLen sizes the destination up front, so marshaling
+// many tables into one reused buffer never pays the bytes.Buffer growth that
+// MarshalBinary does — at most one allocation when dst lacks capacity, none
+// when it doesn't.
+func (t *Table) AppendBinary(dst []byte) ([]byte, error) {
+	if need := t.SerializedLen(); cap(dst)-len(dst) < need {
+		grown := make([]byte, len(dst), len(dst)+need)
+		copy(grown, dst)
+		dst = grown
+	}
+	w := sliceWriter{buf: dst}
+	if _, err := t.WriteTo(&w); err != nil {
+		return dst, err
+	}
+	return w.buf, nil
+}
+
+// sliceWriter adapts an append target to io.Writer for AppendBinary.
+type sliceWriter struct{ buf []byte }
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *Table) UnmarshalBinary(data []byte) error {
+	_, err := t.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// rebuildIndices reconstructs byteCodes, shortCodes, and hashTab from the
+// finalized symbols. It preserves existing code assignments (already set in
+// symbols[i]) and only rebuilds the derived lookup structures. Safe to call
+// multiple times; it is a no-op if accelReady is already true.
+func (t *Table) rebuildIndices() {
+	if t.accelReady {
+		return
+	}
+	// 1) Reset to defaults
+	// byteCodes default to ESCAPE (fsstCodeMask) with len=1 marker
+	for i := range 256 {
+		t.byteCodes[i] = packCodeLength(fsstCodeMask, 1)
+	}
+	// Clear hash tables
+	empty := symbol{}
+	empty.val = 0
+	empty.icl = fsstICLFree
+	for i := range fsstHashTabSize {
+		t.hashTab[i] = empty
+		t.hashTab3[i] = empty
+		t.hashTab4[i] = empty
+		t.hashTab5[i] = empty
+		t.hashTab6[i] = empty
+		t.hashTab7[i] = empty
+		t.hashTab8[i] = empty
+	}
+
+	// 2) Apply single-byte symbols to byteCodes
+	for i := range int(t.nSymbols) {
+		sym := t.symbols[i]
+		length := sym.length()
+		if length == 1 {
+			firstByte := sym.first()
+			t.byteCodes[firstByte] = packCodeLength(uint16(i), 1)
+		}
+	}
+
+	// 3) Initialize shortCodes to mirror byteCodes of the first byte
+	for i := range 65536 {
+		first := i & fsstMask8
+		t.shortCodes[i] = t.byteCodes[first]
+	}
+
+	// 4) Apply two-byte symbols to shortCodes
+	for i := range int(t.nSymbols) {
+		sym := t.symbols[i]
+		if sym.length() == 2 {
+			t.shortCodes[sym.first2()] = packCodeLength(uint16(i), 2)
+		}
+	}
+
+	// 5) Insert 3+ byte symbols into hash tables
+	for i := range int(t.nSymbols) {
+		sym := t.symbols[i]
+		length := sym.length()
+		switch length {
+		case 3:
+			_ = t.hashInsert(sym) // For training
+			t.hashInsert3(sym)
+		case 4:
+			_ = t.hashInsert(sym) // For training
+			t.hashInsert4(sym)
+		case 5:
+			_ = t.hashInsert(sym) // For training
+			t.hashInsert5(sym)
+		case 6:
+			_ = t.hashInsert(sym) // For training
+			t.hashInsert6(sym)
+		case 7:
+			_ = t.hashInsert(sym) // For training
+			t.hashInsert7(sym)
+		case 8:
+			_ = t.hashInsert(sym) // For training
+			t.hashInsert8(sym)
+		}
+	}
+
+	t.accelReady = true
+}
+
+// ensureEncoder lazily prepares the encoder: lookup structures, strategy
+// flags (unless forced via SetVariant), and the scratch chunk buffer.
+func (t *Table) ensureEncoder() {
+	if t.encBuf != nil {
+		return
+	}
+	if !t.accelReady {
+		t.rebuildIndices()
+	}
+	if !t.variantForced {
+		t.noSuffixOpt, t.avoidBranch = chooseVariant(t)
+	}
+	t.encBuf = make([]byte, t.chunkLen()+fsstChunkPadding)
+}
+
+// chunkLen returns the encode chunk length: the SetChunkSize override when
+// one is set, the fsstChunkSize default otherwise.
+func (t *Table) chunkLen() int {
+	if t.chunkSize != 0 {
+		return t.chunkSize
+	}
+	return fsstChunkSize
+}
+
+// SetChunkSize overrides the length of the chunks Encode processes input in.
+// Larger chunks can encode faster on CPUs with large L1 caches; smaller ones
+// help cache-starved machines. Output is identical across chunk sizes — only
+// throughput changes. n must be at least 8 so the fast path always has a full
+// word to load; the scratch buffers keep their fsstChunkPadding slack at any
+// size. Must not be called concurrently with Encode.
+func (t *Table) SetChunkSize(n int) error {
+	if n < 8 || n > MaxEncodeLen/2 {
+		return ErrBadChunkSize
+	}
+	t.chunkSize = n
+	t.encBuf = nil // resized on next Encode
+	t.lenBuf = nil
+	return nil
+}
+
+// Encode compresses input, optionally reusing buf for output.
+// buf can be nil or undersized; it will be grown as needed.
+// Returns the compressed data (may have different backing array than buf).
+//
+// For a fixed table and input, the output is byte-identical across
+// architectures and Go versions: every multi-byte load and store goes through
+// encoding/binary little-endian routines (see fsstUnalignedLoad), so there is
+// no dependence on native endianness or alignment. Stored compressed blobs
+// remain decodable by later binaries; TestEncodeGolden pins this.
+//
+// Inputs longer than MaxEncodeLen would overflow the worst-case output size
+// and panic; use EncodeLimited for a checked variant or EncodeStream for
+// unbounded data.
+func (t *Table) Encode(buf, input []byte) []byte {
+	if len(input) > MaxEncodeLen {
+		panic("fsst: input exceeds MaxEncodeLen; use EncodeStream for larger data")
+	}
+	if t.backrefWindow > 0 {
+		return t.encodeBackrefs(buf, input)
+	}
+	if t.ordered {
+		return t.encodeOrdered(buf, input)
+	}
+	t.ensureEncoder()
+
+	if buf == nil {
 		buf = make([]byte, 2*len(input)+fsstOutputPadding)
 	} else if cap(buf) < 2*len(input)+fsstOutputPadding {
 		buf = make([]byte, 2*len(input)+fsstOutputPadding)
@@ -533,14 +1134,25 @@ func (t *Table) Encode(buf, input []byte) []byte {
 
 	outPos := 0
 	inputLen := len(input)
+	chunkLen := t.chunkLen()
 	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
 
-	// Process input directly: fast path while >=8 bytes remain, then tail
+	// Process input directly: fast path while >=8 bytes remain, then tail.
+	// A match may run up to 7 bytes past the soft chunk end (limit = end+7,
+	// always within the input here), so chunk boundaries never change output.
+	// This also covers an 8-byte symbol ending exactly at EOF: the loop
+	// still runs when exactly 8 bytes remain, so only a sub-symbol-length
+	// remainder ever reaches the padded tail buffer below.
 	position := 0
 	for position+8 <= inputLen {
-		chunkEnd := min(position+fsstChunkSize, inputLen-7)
-		outPos = t.encodeChunk(buf, outPos, input[position:], chunkEnd-position, byteLim)
-		position = chunkEnd
+		end := min(position+chunkLen, inputLen-7) - position
+		var consumed int
+		chunkStart := outPos
+		outPos, consumed = t.encodeChunk(buf, outPos, input[position:], end, end+7, byteLim)
+		position += consumed
+		if t.observer != nil {
+			t.observer.OnEncodeChunk(consumed, outPos-chunkStart)
+		}
 	}
 
 	// Handle tail (<8 bytes): copy to buffer with padding for safe unaligned loads
@@ -548,11 +1160,144 @@ func (t *Table) Encode(buf, input []byte) []byte {
 		chunkBuf := t.encBuf
 		tailLen := inputLen - position
 		copy(chunkBuf[:tailLen], input[position:])
-		outPos = t.encodeChunk(buf, outPos, chunkBuf, tailLen, byteLim)
+		// Zero the full padding region so unaligned loads never see stale
+		// bytes from a previous encode (keeps output deterministic).
+		clear(chunkBuf[tailLen : tailLen+8])
+		chunkStart := outPos
+		outPos, _ = t.encodeChunk(buf, outPos, chunkBuf, tailLen, tailLen, byteLim)
+		if t.observer != nil {
+			t.observer.OnEncodeChunk(tailLen, outPos-chunkStart)
+		}
+	}
+	if t.hasMacros {
+		return t.rewriteMacros(buf[:outPos])
+	}
+	if t.freqRemap != nil {
+		t.remapWireCodes(buf[:outPos])
 	}
 	return buf[:outPos]
 }
 
+// AppendEncode appends the encoded form of input to dst, growing it as
+// needed, and returns the extended slice (append-style convention). When dst
+// has spare capacity the encoder writes into it directly.
+func (t *Table) AppendEncode(dst, input []byte) []byte {
+	// Encode into dst's unused tail; if capacity suffices the output already
+	// sits in place and append only fixes up the length.
+	out := t.Encode(dst[len(dst):], input)
+	return append(dst, out...)
+}
+
+// EncodeAppendAt encodes input into dst starting at dst[offset] and returns
+// the write position after the encoded bytes. It never allocates or grows
+// dst: the caller guarantees capacity for the worst case of
+// 2*len(input)+fsstOutputPadding bytes past offset, and the method panics if
+// that contract is broken. Built for zero-GC steady state, e.g. encoding log
+// lines into a preallocated ring buffer.
+func (t *Table) EncodeAppendAt(dst []byte, offset int, input []byte) (newOffset int) {
+	t.ensureEncoder()
+	if len(input) > MaxEncodeLen {
+		panic("fsst: input exceeds MaxEncodeLen; use EncodeStream for larger data")
+	}
+	if need := 2*len(input) + fsstOutputPadding; len(dst)-offset < need {
+		panic("fsst: EncodeAppendAt destination too small for worst-case output")
+	}
+	if t.ordered {
+		return t.encodeOrderedAt(dst, offset, input)
+	}
+
+	outPos := offset
+	inputLen := len(input)
+	chunkLen := t.chunkLen()
+	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+
+	position := 0
+	for position+8 <= inputLen {
+		end := min(position+chunkLen, inputLen-7) - position
+		var consumed int
+		outPos, consumed = t.encodeChunk(dst, outPos, input[position:], end, end+7, byteLim)
+		position += consumed
+	}
+	if position < inputLen {
+		chunkBuf := t.encBuf
+		tailLen := inputLen - position
+		copy(chunkBuf[:tailLen], input[position:])
+		clear(chunkBuf[tailLen : tailLen+8])
+		outPos, _ = t.encodeChunk(dst, outPos, chunkBuf, tailLen, tailLen, byteLim)
+	}
+	if t.hasMacros {
+		return offset + len(t.rewriteMacros(dst[offset:outPos]))
+	}
+	if t.freqRemap != nil {
+		t.remapWireCodes(dst[offset:outPos])
+	}
+	return outPos
+}
+
+// AppendDecode appends the decoded form of src to dst, growing it as needed,
+// and returns the extended slice.
+func (t *Table) AppendDecode(dst, src []byte) []byte {
+	out := t.Decode(dst[len(dst):], src)
+	return append(dst, out...)
+}
+
+// EncodedLen returns the exact number of bytes Encode would produce for
+// input, without allocating or retaining the encoded output. It runs the same
+// chunk pipeline as Encode but emits into a small reusable scratch buffer.
+func (t *Table) EncodedLen(input []byte) int {
+	if t.hasMacros || t.backrefWindow > 0 || t.ordered {
+		// Macro rewriting pairs codes across chunk boundaries, backref
+		// matching replaces whole stretches, and ordered tables use the trie
+		// encoder, so the per-chunk simulation would miscount; fall back to
+		// encoding.
+		return len(t.Encode(nil, input))
+	}
+	t.ensureEncoder()
+	if t.lenBuf == nil {
+		// Worst case per chunk: every byte escapes (2 output bytes per input
+		// byte), and a chunk may consume up to 7 bytes past its soft end.
+		t.lenBuf = make([]byte, 2*(t.chunkLen()+7)+fsstOutputPadding)
+	}
+
+	total := 0
+	inputLen := len(input)
+	chunkLen := t.chunkLen()
+	byteLim := uint8(t.nSymbols) - uint8(t.lenHisto[0])
+
+	position := 0
+	for position+8 <= inputLen {
+		end := min(position+chunkLen, inputLen-7) - position
+		n, consumed := t.encodeChunk(t.lenBuf, 0, input[position:], end, end+7, byteLim)
+		total += n
+		position += consumed
+	}
+	if position < inputLen {
+		chunkBuf := t.encBuf
+		tailLen := inputLen - position
+		copy(chunkBuf[:tailLen], input[position:])
+		clear(chunkBuf[tailLen : tailLen+8]) // no stale bytes under unaligned loads
+		n, _ := t.encodeChunk(t.lenBuf, 0, chunkBuf, tailLen, tailLen, byteLim)
+		total += n
+	}
+	return total
+}
+
+// EstimateRatio returns the compression ratio (compressed size / original
+// size) that Encode would achieve over inputs, without allocating encoded
+// output. A value below 1 means the data compresses. Returns 0 if inputs
+// contain no bytes.
+func (t *Table) EstimateRatio(inputs [][]byte) float64 {
+	var inputBytes, encodedBytes int
+	for i := range inputs {
+		inputBytes += len(inputs[i])
+		encodedBytes += t.EncodedLen(inputs[i])
+	}
+	if inputBytes == 0 {
+		return 0
+	}
+	return float64(encodedBytes) / float64(inputBytes)
+}
+
 // EncodeAll compresses input and returns a newly allocated byte slice.
 func (t *Table) EncodeAll(input []byte) []byte {
 	return t.Encode(nil, input)
@@ -561,7 +1306,11 @@ func (t *Table) EncodeAll(input []byte) []byte {
 // encodeChunk compresses a single chunk using index-based writes.
 // dst is the output buffer, dstPos is the starting write position.
 // buf must have at least 8 bytes of padding after end for safe unaligned loads.
-// Returns the new output position.
+// Matches start while position < end but may consume bytes up to limit, so a
+// symbol straddling a chunk boundary is never cut short — callers pass
+// limit > end for interior chunks (keeping output independent of the chunk
+// size) and limit == end at the true end of the input. Returns the new output
+// position and the number of input bytes consumed (in [end, limit]).
 //
 // Match order (fastest first):
 // 1) Optional fast 2-byte unique-prefix path (when noSuffixOpt is true)
@@ -572,22 +1321,107 @@ func (t *Table) EncodeAll(input []byte) []byte {
 // Strategy flags:
 // - noSuffixOpt: skip suffix checking for most 2-byte symbols (higher hit rate)
 // - avoidBranch: use branchless emission when distribution makes branches costly
-func (t *Table) encodeChunk(dst []byte, dstPos int, buf []byte, end int, byteLim uint8) int {
+func (t *Table) encodeChunk(dst []byte, dstPos int, buf []byte, end, limit int, byteLim uint8) (int, int) {
+	// Extended code space uses its own (single) variant with 16-bit codes
+	if t.extended {
+		return t.encodeChunkExtended(dst, dstPos, buf, end, limit, t.nSymbols-t.lenHisto[0])
+	}
+	// ASCII-only hint: high-bit input bytes can never match a symbol, so a
+	// dedicated loop escapes them without probing any lookup structure.
+	if t.asciiOnly && !t.variantForced {
+		return t.encodeChunkASCII(dst, dstPos, buf, end, limit, byteLim)
+	}
 	// Hoist strategy checks outside hot loop to eliminate redundant field access
 	if t.avoidBranch {
 		if t.noSuffixOpt {
-			return t.encodeChunkBranchlessNoSuffix(dst, dstPos, buf, end)
+			return t.encodeChunkBranchlessNoSuffix(dst, dstPos, buf, end, limit)
 		}
-		return t.encodeChunkBranchless(dst, dstPos, buf, end, byteLim)
+		return t.encodeChunkBranchless(dst, dstPos, buf, end, limit, byteLim)
 	}
 	if t.noSuffixOpt {
-		return t.encodeChunkBranchedNoSuffix(dst, dstPos, buf, end)
+		return t.encodeChunkBranchedNoSuffix(dst, dstPos, buf, end, limit)
+	}
+	return t.encodeChunkBranched(dst, dstPos, buf, end, limit, byteLim)
+}
+
+// encodeChunkASCII mirrors encodeChunkBranched for tables trained on pure
+// 7-bit data (asciiOnly): a high-bit input byte can never match any symbol,
+// so it escapes immediately without touching shortCodes or the hash tables.
+// On pure-ASCII input the extra test is a single predictable branch.
+func (t *Table) encodeChunkASCII(dst []byte, dstPos int, buf []byte, end, limit int, byteLim uint8) (int, int) {
+	position := 0
+
+	for position < end {
+		if b := buf[position]; b&0x80 != 0 {
+			dst[dstPos] = fsstEscapeCode
+			dst[dstPos+1] = b
+			dstPos += 2
+			position++
+			continue
+		}
+		word := fsstUnalignedLoad(buf[position:])
+		code := t.shortCodes[uint16(word&fsstMask16)]
+		codeU8 := uint8(code)
+
+		// Check if 2-byte shortCode is valid before hash lookup
+		// But only if we have at least 2 bytes remaining
+		if codeU8 < byteLim && position+2 <= limit {
+			dst[dstPos] = codeU8
+			dstPos++
+			position += 2
+			continue
+		}
+
+		// Length-specific hash table lookup for 3+ byte matches
+		prefix24 := word & fsstMask24
+		hashIndex := fsstHash(prefix24) & (fsstHashTabSize - 1)
+		var hashSymbol symbol
+		var found bool
+
+		// Probe length-specific tables (8→7→6→5→4→3)
+		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFFFFFFFF) && position+7 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFFFFFF) && position+6 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFFFF) && position+5 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFFFF) && position+4 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word&0xFFFFFF) && position+3 <= limit {
+			found = true
+		}
+
+		if found {
+			dst[dstPos] = uint8(hashSymbol.code())
+			dstPos++
+			position += int(hashSymbol.length())
+		} else {
+			// 1-byte or escape fallback
+			escapeByte := uint8(word)
+
+			// If shortCodes gave us a 2-byte code but we only have 1 byte, use byteCodes instead
+			advance := int(code >> fsstLenBits)
+			if position+advance > limit {
+				code = t.byteCodes[escapeByte]
+			}
+
+			codeU8 = uint8(code)
+			dst[dstPos] = codeU8
+			dstPos++
+			if (code & fsstCodeBase) != 0 {
+				dst[dstPos] = escapeByte
+				dstPos++
+			}
+			position++
+		}
 	}
-	return t.encodeChunkBranched(dst, dstPos, buf, end, byteLim)
+	return dstPos, position
 }
 
 // encodeChunkBranchedNoSuffix: noSuffixOpt=true, avoidBranch=false
-func (t *Table) encodeChunkBranchedNoSuffix(dst []byte, dstPos int, buf []byte, end int) int {
+func (t *Table) encodeChunkBranchedNoSuffix(dst []byte, dstPos int, buf []byte, end, limit int) (int, int) {
 	position := 0
 	suffixLim := uint8(t.suffixLim)
 
@@ -597,7 +1431,7 @@ func (t *Table) encodeChunkBranchedNoSuffix(dst []byte, dstPos int, buf []byte,
 
 		// Fast path: 2-byte code without suffix check
 		// But only if we have at least 2 bytes remaining
-		if uint8(code) < suffixLim && position+2 <= end {
+		if uint8(code) < suffixLim && position+2 <= limit {
 			dst[dstPos] = uint8(code)
 			dstPos++
 			position += 2
@@ -611,17 +1445,17 @@ func (t *Table) encodeChunkBranchedNoSuffix(dst []byte, dstPos int, buf []byte,
 		var found bool
 
 		// Probe length-specific tables (8→7→6→5→4→3)
-		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= end {
+		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFFFF) && position+7 <= end {
+		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFFFF) && position+7 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFF) && position+6 <= end {
+		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFF) && position+6 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFF) && position+5 <= end {
+		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFF) && position+5 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFF) && position+4 <= end {
+		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFF) && position+4 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFF) && position+3 <= end {
+		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFF) && position+3 <= limit {
 			found = true
 		}
 
@@ -635,7 +1469,7 @@ func (t *Table) encodeChunkBranchedNoSuffix(dst []byte, dstPos int, buf []byte,
 
 			// If shortCodes gave us a 2-byte code but we only have 1 byte, use byteCodes instead
 			advance := int(code >> fsstLenBits)
-			if position+advance > end {
+			if position+advance > limit {
 				code = t.byteCodes[escapeByte]
 			}
 
@@ -649,11 +1483,11 @@ func (t *Table) encodeChunkBranchedNoSuffix(dst []byte, dstPos int, buf []byte,
 			position++
 		}
 	}
-	return dstPos
+	return dstPos, position
 }
 
 // encodeChunkBranched: noSuffixOpt=false, avoidBranch=false
-func (t *Table) encodeChunkBranched(dst []byte, dstPos int, buf []byte, end int, byteLim uint8) int {
+func (t *Table) encodeChunkBranched(dst []byte, dstPos int, buf []byte, end, limit int, byteLim uint8) (int, int) {
 	position := 0
 
 	for position < end {
@@ -663,7 +1497,7 @@ func (t *Table) encodeChunkBranched(dst []byte, dstPos int, buf []byte, end int,
 
 		// Check if 2-byte shortCode is valid before hash lookup
 		// But only if we have at least 2 bytes remaining
-		if codeU8 < byteLim && position+2 <= end {
+		if codeU8 < byteLim && position+2 <= limit {
 			dst[dstPos] = codeU8
 			dstPos++
 			position += 2
@@ -677,17 +1511,17 @@ func (t *Table) encodeChunkBranched(dst []byte, dstPos int, buf []byte, end int,
 		var found bool
 
 		// Probe length-specific tables (8→7→6→5→4→3)
-		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= end {
+		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFFFF) && position+7 <= end {
+		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFFFF) && position+7 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFF) && position+6 <= end {
+		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFF) && position+6 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFF) && position+5 <= end {
+		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFF) && position+5 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFF) && position+4 <= end {
+		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFF) && position+4 <= limit {
 			found = true
-		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFF) && position+3 <= end {
+		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFF) && position+3 <= limit {
 			found = true
 		}
 
@@ -701,7 +1535,7 @@ func (t *Table) encodeChunkBranched(dst []byte, dstPos int, buf []byte, end int,
 
 			// If shortCodes gave us a 2-byte code but we only have 1 byte, use byteCodes instead
 			advance := int(code >> fsstLenBits)
-			if position+advance > end {
+			if position+advance > limit {
 				code = t.byteCodes[escapeByte]
 			}
 
@@ -715,11 +1549,11 @@ func (t *Table) encodeChunkBranched(dst []byte, dstPos int, buf []byte, end int,
 			position++
 		}
 	}
-	return dstPos
+	return dstPos, position
 }
 
 // encodeChunkBranchlessNoSuffix: noSuffixOpt=true, avoidBranch=true
-func (t *Table) encodeChunkBranchlessNoSuffix(dst []byte, dstPos int, buf []byte, end int) int {
+func (t *Table) encodeChunkBranchlessNoSuffix(dst []byte, dstPos int, buf []byte, end, limit int) (int, int) {
 	position := 0
 	suffixLim := uint8(t.suffixLim)
 
@@ -728,7 +1562,7 @@ func (t *Table) encodeChunkBranchlessNoSuffix(dst []byte, dstPos int, buf []byte
 		code := t.shortCodes[uint16(word&fsstMask16)]
 
 		// Fast path: 2-byte code without suffix check
-		if uint8(code) < suffixLim && position+2 <= end {
+		if uint8(code) < suffixLim && position+2 <= limit {
 			dst[dstPos] = uint8(code)
 			dstPos++
 			position += 2
@@ -743,7 +1577,7 @@ func (t *Table) encodeChunkBranchlessNoSuffix(dst []byte, dstPos int, buf []byte
 		maskedWord := word & symbolMask
 		symbolLen := int(hashSymbol.length())
 
-		if hashSymbol.icl < fsstICLFree && hashSymbol.val == maskedWord && position+symbolLen <= end {
+		if hashSymbol.icl < fsstICLFree && hashSymbol.val == maskedWord && position+symbolLen <= limit {
 			dst[dstPos] = uint8(hashSymbol.code())
 			dstPos++
 			position += symbolLen
@@ -753,7 +1587,7 @@ func (t *Table) encodeChunkBranchlessNoSuffix(dst []byte, dstPos int, buf []byte
 			escapeByte := uint8(word)
 
 			// If we'd read past the end, use byteCodes for single byte instead
-			if position+advance > end {
+			if position+advance > limit {
 				code = t.byteCodes[escapeByte]
 				advance = 1
 			}
@@ -768,11 +1602,11 @@ func (t *Table) encodeChunkBranchlessNoSuffix(dst []byte, dstPos int, buf []byte
 			position += advance
 		}
 	}
-	return dstPos
+	return dstPos, position
 }
 
 // encodeChunkBranchless: noSuffixOpt=false, avoidBranch=true
-func (t *Table) encodeChunkBranchless(dst []byte, dstPos int, buf []byte, end int, byteLim uint8) int {
+func (t *Table) encodeChunkBranchless(dst []byte, dstPos int, buf []byte, end, limit int, byteLim uint8) (int, int) {
 	position := 0
 
 	for position < end {
@@ -787,7 +1621,7 @@ func (t *Table) encodeChunkBranchless(dst []byte, dstPos int, buf []byte, end in
 		maskedWord := word & symbolMask
 		symbolLen := int(hashSymbol.length())
 
-		if hashSymbol.icl < fsstICLFree && hashSymbol.val == maskedWord && position+symbolLen <= end {
+		if hashSymbol.icl < fsstICLFree && hashSymbol.val == maskedWord && position+symbolLen <= limit {
 			dst[dstPos] = uint8(hashSymbol.code())
 			dstPos++
 			position += symbolLen
@@ -797,7 +1631,7 @@ func (t *Table) encodeChunkBranchless(dst []byte, dstPos int, buf []byte, end in
 			escapeByte := uint8(word)
 
 			// If we'd read past the end, use byteCodes for single byte instead
-			if position+advance > end {
+			if position+advance > limit {
 				code = t.byteCodes[escapeByte]
 				advance = 1
 			}
@@ -812,22 +1646,255 @@ func (t *Table) encodeChunkBranchless(dst []byte, dstPos int, buf []byte, end in
 			position += advance
 		}
 	}
-	return dstPos
+	return dstPos, position
+}
+
+// ensureDecoder lazily builds the flat decoder arrays (decLen/decSymbol).
+func (t *Table) ensureDecoder() {
+	if t.decReady {
+		return
+	}
+	for code := uint16(0); code < t.nSymbols; code++ {
+		sym := t.symbols[code]
+		wire := code
+		if t.freqRemap != nil {
+			wire = uint16(t.freqRemap[code])
+		}
+		t.decLen[wire] = byte(sym.length())
+		t.decSymbol[wire] = sym.val
+	}
+	t.decReady = true
+}
+
+// WarmDecoder builds the decoder lookup arrays eagerly, so that subsequent
+// Decode calls from multiple goroutines never race on the lazy build. Call it
+// once after training or deserialization if the Table will be shared for
+// concurrent decoding.
+func (t *Table) WarmDecoder() {
+	if t.extended {
+		t.ensureDecoderExtended()
+	} else {
+		t.ensureDecoder()
+	}
+}
+
+// Refresh discards every lazily built lookup structure — encoder indices,
+// scratch buffers, and decoder arrays — so the next Encode or Decode rebuilds
+// them from the current symbols. Call it after mutating a Table in place
+// (e.g. reusing one struct across ReadFrom calls combined with manual symbol
+// edits); without it the stale accelReady/decReady caches would keep encoding
+// with the previous table's layout.
+func (t *Table) Refresh() {
+	t.invalidateCaches()
+}
+
+// invalidateCaches clears the lazy encoder and decoder state. The fixed-size
+// decoder arrays need no clearing — ensureDecoder overwrites the populated
+// prefix — but the extended arrays are dropped so they get resized.
+func (t *Table) invalidateCaches() {
+	t.accelReady = false
+	t.decReady = false
+	t.encBuf = nil
+	t.lenBuf = nil
+	t.frameBuf = nil
+	t.decLenExt = nil
+	t.decSymbolExt = nil
+	t.uniformLen = computeUniformLen(t.lenHisto)
+}
+
+// Prepare eagerly runs all lazy initialization — the encoder lookup
+// structures, strategy selection, the scratch chunk buffer, and the decoder
+// arrays — so the cost is paid at startup instead of on the first Encode or
+// Decode. After Prepare, the first call in either direction allocates nothing
+// beyond its output. Useful in latency-sensitive services where first-request
+// jitter matters.
+func (t *Table) Prepare() {
+	t.ensureEncoder()
+	t.WarmDecoder()
+}
+
+// Clone returns an independent copy of the Table with its own scratch
+// buffers, for handing to another encoding goroutine. The symbol data is
+// copied by value; training state is shared read-only.
+func (t *Table) Clone() *Table {
+	clone := *t
+	clone.encBuf = nil
+	clone.lenBuf = nil
+	clone.frameBuf = nil
+	return &clone
+}
+
+// ensureDecoderExtended lazily builds the extended-mode decoder arrays,
+// sized for the extended code space.
+func (t *Table) ensureDecoderExtended() {
+	if t.decReady {
+		return
+	}
+	t.decLenExt = make([]byte, t.nSymbols)
+	t.decSymbolExt = make([]uint64, t.nSymbols)
+	for code := uint16(0); code < t.nSymbols; code++ {
+		sym := t.symbols[code]
+		t.decLenExt[code] = byte(sym.length())
+		t.decSymbolExt[code] = sym.val
+	}
+	t.decReady = true
+}
+
+// DecodedLen returns the exact number of bytes Decode would produce for src
+// in a single cheap pass, so output buffers can be sized exactly once. Escape
+// codes contribute one byte and consume two source bytes; unknown codes
+// contribute nothing, matching Decode's behavior on malformed input.
+func (t *Table) DecodedLen(src []byte) int {
+	total := 0
+	if t.extended {
+		t.ensureDecoderExtended()
+		for srcPos := 0; srcPos < len(src); {
+			b := src[srcPos]
+			srcPos++
+			switch b {
+			case fsstEscapeCode:
+				if srcPos < len(src) {
+					total++
+					srcPos++
+				}
+			case fsstExtendedPrefix:
+				if srcPos < len(src) {
+					code := fsstExtendedPrefix + uint16(src[srcPos])
+					srcPos++
+					if int(code) < len(t.decLenExt) {
+						total += int(t.decLenExt[code])
+					}
+				}
+			default:
+				if int(b) < len(t.decLenExt) {
+					total += int(t.decLenExt[b])
+				}
+			}
+		}
+		return total
+	}
+	t.ensureDecoder()
+	for srcPos := 0; srcPos < len(src); {
+		code := src[srcPos]
+		srcPos++
+		if code < fsstEscapeCode {
+			if t.hasMacros && uint16(code) >= t.macroBase {
+				if idx := uint16(code) - t.macroBase; int(idx) < len(t.macros) {
+					pair := t.macros[idx]
+					total += int(t.decLen[pair[0]]) + int(t.decLen[pair[1]])
+				}
+				continue
+			}
+			if t.backrefWindow > 0 && uint16(code) == t.nSymbols {
+				if srcPos+1 < len(src) {
+					total += int(src[srcPos+1]) // triple: marker, distance, length
+				}
+				srcPos += 2
+				continue
+			}
+			total += int(t.decLen[code])
+		} else if srcPos < len(src) {
+			total++
+			srcPos++
+		}
+	}
+	return total
+}
+
+// emitExtendedCode writes a learned code in extended-mode wire form:
+// single byte for codes below the prefix, otherwise prefix byte + offset.
+func emitExtendedCode(dst []byte, dstPos int, code uint16) int {
+	if code < fsstExtendedPrefix {
+		dst[dstPos] = uint8(code)
+		return dstPos + 1
+	}
+	dst[dstPos] = fsstExtendedPrefix
+	dst[dstPos+1] = uint8(code - fsstExtendedPrefix)
+	return dstPos + 2
+}
+
+// encodeChunkExtended compresses a chunk in extended-code mode. It mirrors
+// encodeChunkBranched but compares full 16-bit codes (extended tables can
+// assign codes above 255) and emits two-byte sequences for high codes.
+func (t *Table) encodeChunkExtended(dst []byte, dstPos int, buf []byte, end, limit int, byteLim uint16) (int, int) {
+	position := 0
+
+	for position < end {
+		word := fsstUnalignedLoad(buf[position:])
+		code := t.shortCodes[uint16(word&fsstMask16)]
+
+		// 2-byte shortCode before hash lookup, full-code comparison
+		if code&fsstCodeMask < byteLim && position+2 <= limit {
+			dstPos = emitExtendedCode(dst, dstPos, code&fsstCodeMask)
+			position += 2
+			continue
+		}
+
+		// Length-specific hash table lookup for 3+ byte matches
+		prefix24 := word & fsstMask24
+		hashIndex := fsstHash(prefix24) & (fsstHashTabSize - 1)
+		var hashSymbol symbol
+		var found bool
+
+		// Probe length-specific tables (8→7→6→5→4→3)
+		if hashSymbol = t.hashTab8[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == word && position+8 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab7[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFFFF) && position+7 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab6[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFFFF) && position+6 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab5[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFFFF) && position+5 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab4[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFFFF) && position+4 <= limit {
+			found = true
+		} else if hashSymbol = t.hashTab3[hashIndex]; hashSymbol.icl < fsstICLFree && hashSymbol.val == (word & 0xFFFFFF) && position+3 <= limit {
+			found = true
+		}
+
+		if found {
+			dstPos = emitExtendedCode(dst, dstPos, hashSymbol.code())
+			position += int(hashSymbol.length())
+		} else {
+			// 1-byte or escape fallback
+			escapeByte := uint8(word)
+
+			// If shortCodes gave us a 2-byte code but we only have 1 byte, use byteCodes instead
+			advance := int(code >> fsstLenBits)
+			if position+advance > limit {
+				code = t.byteCodes[escapeByte]
+			}
+
+			if code&fsstCodeMask == fsstCodeMask {
+				// No learned 1-byte symbol: escape the literal
+				dst[dstPos] = fsstEscapeCode
+				dst[dstPos+1] = escapeByte
+				dstPos += 2
+			} else {
+				dstPos = emitExtendedCode(dst, dstPos, code&fsstCodeMask)
+			}
+			position++
+		}
+	}
+	return dstPos, position
 }
 
 // Decode decompresses src, optionally reusing buf for output.
 // buf can be nil or undersized; it will be grown as needed.
 // Returns the decompressed data (may have different backing array than buf).
 func (t *Table) Decode(buf, src []byte) []byte {
-	// Lazy-initialize decoder structures
-	if !t.decReady {
-		for code := uint16(0); code < t.nSymbols; code++ {
-			sym := t.symbols[code]
-			t.decLen[code] = byte(sym.length())
-			t.decSymbol[code] = sym.val
-		}
-		t.decReady = true
+	if t.extended {
+		return t.decodeExtended(buf, src)
+	}
+	if t.hasMacros {
+		return t.decodeMacros(buf, src)
 	}
+	if t.backrefWindow > 0 {
+		return t.decodeBackrefs(buf, src)
+	}
+	if t.uniformLen != 0 {
+		return t.decodeUniform(buf, src)
+	}
+	t.ensureDecoder()
 
 	if buf == nil {
 		buf = make([]byte, 0, len(src)*4+8)
@@ -907,16 +1974,141 @@ func (t *Table) Decode(buf, src []byte) []byte {
 	return buf[:bufPos]
 }
 
+// decodeUniform decompresses a stream whose table has a single symbol length
+// (uniformLen): no per-code length lookup, one unconditional 8-byte store and
+// a constant stride per code. The buffer is sized for the worst case up
+// front, so the loop carries no capacity checks.
+func (t *Table) decodeUniform(buf, src []byte) []byte {
+	t.ensureDecoder()
+
+	stride := int(t.uniformLen)
+	// Worst case: every source byte is a code of stride bytes; +8 keeps the
+	// unconditional 8-byte stores in bounds.
+	need := len(src)*stride + 8
+	if cap(buf) < need {
+		buf = make([]byte, need)
+	} else {
+		buf = buf[:cap(buf)]
+	}
+
+	bufPos := 0
+	for srcPos := 0; srcPos < len(src); {
+		code := src[srcPos]
+		srcPos++
+		if code < fsstEscapeCode {
+			binary.LittleEndian.PutUint64(buf[bufPos:], t.decSymbol[code])
+			bufPos += stride
+		} else {
+			if srcPos >= len(src) {
+				break
+			}
+			buf[bufPos] = src[srcPos]
+			bufPos++
+			srcPos++
+		}
+	}
+	return buf[:bufPos]
+}
+
+// decodeExtended decompresses an extended-mode code stream. Byte 255 escapes
+// a literal, byte 254 prefixes a high code, everything else is a direct code.
+// Unknown codes are skipped, matching Decode's tolerance of malformed input.
+func (t *Table) decodeExtended(buf, src []byte) []byte {
+	t.ensureDecoderExtended()
+
+	if buf == nil {
+		buf = make([]byte, 0, len(src)*4+8)
+	} else {
+		buf = buf[:0] // Reset length but keep capacity
+	}
+
+	var tmp [8]byte
+	srcPos := 0
+	for srcPos < len(src) {
+		b := src[srcPos]
+		srcPos++
+
+		var code uint16
+		switch b {
+		case fsstEscapeCode:
+			// Escape code: next byte is literal
+			if srcPos >= len(src) {
+				return buf
+			}
+			buf = append(buf, src[srcPos])
+			srcPos++
+			continue
+		case fsstExtendedPrefix:
+			if srcPos >= len(src) {
+				return buf
+			}
+			code = fsstExtendedPrefix + uint16(src[srcPos])
+			srcPos++
+		default:
+			code = uint16(b)
+		}
+		if int(code) >= len(t.decLenExt) {
+			continue
+		}
+		binary.LittleEndian.PutUint64(tmp[:], t.decSymbolExt[code])
+		buf = append(buf, tmp[:t.decLenExt[code]]...)
+	}
+	return buf
+}
+
 // DecodeAll decompresses src and returns a newly allocated byte slice with the result.
 func (t *Table) DecodeAll(src []byte) []byte {
 	return t.Decode(nil, src)
 }
 
+// DecodeWithPool decompresses src using a buffer drawn from pool, reaching
+// steady-state zero allocations when decoding similarly-sized records. The
+// pool stores []byte values and may start empty. The caller owns the returned
+// slice and should hand it back via pool.Put once done with its contents;
+// buffers grown during decode keep their larger capacity when recycled.
+func (t *Table) DecodeWithPool(pool *sync.Pool, src []byte) []byte {
+	var buf []byte
+	if v := pool.Get(); v != nil {
+		buf = v.([]byte)
+	}
+	return t.Decode(buf, src)
+}
+
+// EncodeString compresses a string, optionally reusing buf for output.
+// It avoids copying s to a []byte; the same buffer-reuse semantics as Encode apply.
+func (t *Table) EncodeString(buf []byte, s string) []byte {
+	return t.Encode(buf, unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
 // DecodeString decompresses a string and returns a newly allocated byte slice.
 func (t *Table) DecodeString(s string) []byte {
 	return t.Decode(nil, unsafe.Slice(unsafe.StringData(s), len(s)))
 }
 
+// DecodeToString decompresses src and returns the result as a string without
+// copying: the string aliases the freshly allocated decode buffer. This is
+// safe because the buffer never escapes elsewhere and strings are immutable
+// from the caller's point of view. Do not recover the underlying bytes (e.g.
+// via unsafe) and mutate them — that would violate Go's string immutability
+// guarantee.
+func (t *Table) DecodeToString(src []byte) string {
+	buf := t.Decode(nil, src)
+	if len(buf) == 0 {
+		return ""
+	}
+	return unsafe.String(&buf[0], len(buf))
+}
+
+// SetVariant forces a specific encoding strategy, overriding the heuristic
+// selection in chooseVariant. Useful when benchmarking shows the heuristic
+// picked the wrong variant for a workload. Must not be called concurrently
+// with Encode. The setting survives the lazy encoder initialization.
+func (t *Table) SetVariant(noSuffixOpt, avoidBranch bool) {
+	t.noSuffixOpt = noSuffixOpt
+	t.avoidBranch = avoidBranch
+	t.variantForced = true
+}
+
 // chooseVariant selects the best encoding strategy based on symbol statistics.
 // Returns flags for two encoding optimizations:
 //   - noSuffixOpt: skip suffix checking for 2-byte symbols (when >65% are 2-byte and >95% have no suffix conflicts)
@@ -120,6 +120,14 @@ func TestDecodeAPIs(t *testing.T) {
 		}
 	})
 
+	// Test EncodeString
+	t.Run("EncodeString", func(t *testing.T) {
+		comp2 := tbl.EncodeString(nil, string(input))
+		if !bytes.Equal(comp2, comp) {
+			t.Fatalf("EncodeString mismatch with Encode output")
+		}
+	})
+
 	// Test DecodeString
 	t.Run("DecodeString", func(t *testing.T) {
 		compStr := string(comp)
@@ -129,6 +137,17 @@ func TestDecodeAPIs(t *testing.T) {
 		}
 	})
 
+	// Test DecodeToString
+	t.Run("DecodeToString", func(t *testing.T) {
+		got := tbl.DecodeToString(comp)
+		if got != string(input) {
+			t.Fatalf("DecodeToString mismatch: got %q, want %q", got, input)
+		}
+		if empty := tbl.DecodeToString(nil); empty != "" {
+			t.Fatalf("DecodeToString(nil) = %q, want empty string", empty)
+		}
+	})
+
 }
 
 // BenchmarkDecode benchmarks different decode scenarios
@@ -0,0 +1,86 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// tailTestTable builds a table holding one 8-byte symbol plus a 1-byte
+// symbol for the filler, via FromProto so the 8-byte symbol is guaranteed
+// rather than hoped for from training. Multi-byte codes precede 1-byte
+// codes, matching finalize's layout.
+func tailTestTable(t *testing.T, token []byte) (*Table, int) {
+	t.Helper()
+	tbl, err := FromProto(&TableProto{
+		Version: fsstVersion,
+		Symbols: [][]byte{token, []byte(".")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code8, ok := tbl.HasSymbol(token)
+	if !ok {
+		t.Fatalf("HasSymbol(%q) = false", token)
+	}
+	return tbl, code8
+}
+
+// TestEightByteSymbolAtEOF verifies the encode tail discipline: an 8-byte
+// symbol whose last byte is the last byte of the input must be emitted as
+// one code, not escaped. The fast loop runs while position+8 <= inputLen
+// with limit = end+7, so the final full-length match is still visible to it;
+// only a sub-8-byte remainder goes through the padded tail buffer.
+func TestEightByteSymbolAtEOF(t *testing.T) {
+	token := []byte("ABCDEFGH")
+	tbl, code8 := tailTestTable(t, token)
+
+	// Cover the tail landing before, on, and after chunk boundaries, for
+	// every encode variant.
+	sizes := []int{8, 15, 16, 511, 512, 513, 519, 1031, 4104}
+	for _, noSuffix := range []bool{false, true} {
+		for _, avoidBranch := range []bool{false, true} {
+			tbl.SetVariant(noSuffix, avoidBranch)
+			for _, size := range sizes {
+				input := append(bytes.Repeat([]byte("."), size-8), token...)
+				comp := tbl.Encode(nil, input)
+				if got := tbl.Decode(nil, comp); !bytes.Equal(got, input) {
+					t.Fatalf("size %d: roundtrip mismatch", size)
+				}
+				// The stream must end with the 8-byte symbol's code.
+				lastCode, escaped := -1, false
+				for i := 0; i < len(comp); i++ {
+					if comp[i] == fsstEscapeCode {
+						i++
+						escaped = true
+						lastCode = -1
+						continue
+					}
+					escaped = false
+					lastCode = int(comp[i])
+				}
+				if escaped || lastCode != code8 {
+					t.Fatalf("noSuffix=%v avoidBranch=%v size %d: tail not matched as symbol %d (lastCode=%d escaped=%v)",
+						noSuffix, avoidBranch, size, code8, lastCode, escaped)
+				}
+			}
+		}
+	}
+}
+
+// TestTailShorterThanSymbol checks the other side of the discipline: when
+// fewer than 8 bytes remain, the 8-byte symbol must not fire on the
+// zero-padded tail buffer and corrupt the output.
+func TestTailShorterThanSymbol(t *testing.T) {
+	token := []byte("ABCDEFGH")
+	tbl, _ := tailTestTable(t, token)
+
+	base := strings.Repeat(".", 32) + string(token)
+	for cut := 1; cut < 8; cut++ {
+		input := []byte(base[:len(base)-cut]) // token truncated at EOF
+		comp := tbl.Encode(nil, input)
+		if got := tbl.Decode(nil, comp); !bytes.Equal(got, input) {
+			t.Fatalf("cut %d: roundtrip mismatch: got %q, want %q", cut, got, input)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package fsst
+
+import (
+	"bytes"
+	"encoding/base64"
+)
+
+// MarshalText implements encoding.TextMarshaler: the serialized table as
+// standard base64, safe to embed in YAML, TOML, or flag values where raw
+// bytes don't fit. The payload is exactly the MarshalBinary form, so a table
+// restored from text produces identical encode output; the JSON form stays
+// the human-readable inspection view and the binary form the compact one.
+func (t *Table) MarshalText() ([]byte, error) {
+	raw, err := t.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	dst := make([]byte, base64.StdEncoding.EncodedLen(len(raw)))
+	base64.StdEncoding.Encode(dst, raw)
+	return dst, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reversing MarshalText.
+// Surrounding whitespace is tolerated, as config loaders tend to leave
+// newlines around block values.
+func (t *Table) UnmarshalText(text []byte) error {
+	text = bytes.TrimSpace(text)
+	raw := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(raw, text)
+	if err != nil {
+		return err
+	}
+	return t.UnmarshalBinary(raw[:n])
+}
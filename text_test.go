@@ -0,0 +1,40 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMarshalTextRoundtrip(t *testing.T) {
+	corpus := []byte(strings.Repeat("embed the table in a text config ", 100))
+	tbl := Train([][]byte{corpus})
+
+	text, err := tbl.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+	// Printable and quote-free: safe to drop into YAML or TOML as-is.
+	for _, b := range text {
+		if b < '+' || b > 'z' {
+			t.Fatalf("MarshalText emitted non-base64 byte %q", b)
+		}
+	}
+
+	var restored Table
+	if err := restored.UnmarshalText(append([]byte("\n  "), append(text, '\n')...)); err != nil {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if !restored.Equal(tbl) {
+		t.Fatal("restored table not Equal to original")
+	}
+	want := tbl.Encode(nil, corpus)
+	if got := restored.Encode(nil, corpus); !bytes.Equal(got, want) {
+		t.Fatal("restored table produces different encode output")
+	}
+
+	// Garbage text is rejected, not decoded into a broken table.
+	if err := restored.UnmarshalText([]byte("not*base64*at*all")); err == nil {
+		t.Fatal("expected error for invalid base64")
+	}
+}
@@ -2,12 +2,14 @@ package fsst
 
 import (
 	"container/heap"
+	"context"
+	"sort"
+	"time"
 	"unsafe"
 )
 
 const (
 	fsstSampleTarget = 1 << 14 // 16KB
-	fsstSampleMaxSz  = 2 * fsstSampleTarget
 	fsstSampleLine   = 512
 
 	singleByteBoost     = 8
@@ -16,41 +18,465 @@ const (
 	rngSeed             = 4637947
 )
 
+// trainConfig collects optional training parameters.
+type trainConfig struct {
+	extendedCodes bool
+	macros        bool
+	runeAligned   bool
+	noPairMerging bool
+	seedSymbols   []symbol
+	skipThreshold float64
+	hashSize      int
+	fullCoverage  bool
+	backrefWindow int
+	sampleLine    int
+	minSymbolLen  int
+	maxSymbolLen  int
+	observer      Observer     // non-nil when WithObserver wires metrics callbacks
+	report        *TrainReport // non-nil when TrainWithReport collects diagnostics
+}
+
+// lineSize returns the sampling window length, defaulting to fsstSampleLine.
+func (cfg *trainConfig) lineSize() int {
+	if cfg.sampleLine > 0 {
+		return cfg.sampleLine
+	}
+	return fsstSampleLine
+}
+
+// TrainOption configures optional training behavior for Train and friends.
+type TrainOption func(*trainConfig)
+
+// WithExtendedCodes enables the extended code space: up to 510 learned
+// symbols instead of 255. Codes beyond the single-byte range are emitted as a
+// two-byte prefixed sequence, trading some encode density for a larger
+// vocabulary on highly diverse corpora. Tables trained in extended mode use a
+// distinct serialization format flag and are not wire-compatible with the
+// default single-byte code stream.
+func WithExtendedCodes(enabled bool) TrainOption {
+	return func(cfg *trainConfig) { cfg.extendedCodes = enabled }
+}
+
+// WithMacros assigns the spare single-byte codes above the learned symbols to
+// frequent pairs of adjacent codes, so that recurring patterns longer than 8
+// bytes compress to a single output byte. Tables trained with macros use a
+// distinct serialization format flag. Incompatible with WithExtendedCodes,
+// which claims the same spare code space; extended mode wins if both are set.
+func WithMacros(enabled bool) TrainOption {
+	return func(cfg *trainConfig) { cfg.macros = enabled }
+}
+
+// WithRuneAlignedSymbols discourages symbols that straddle UTF-8 rune
+// boundaries — starting on a continuation byte or ending mid-sequence — by
+// penalizing their gain during candidate selection. Useful when decoded
+// prefixes must later be cut at rune boundaries. It is a training-only
+// heuristic: the wire format and decode path are unchanged, and straddling
+// symbols may still be chosen when nothing better exists.
+func WithRuneAlignedSymbols(enabled bool) TrainOption {
+	return func(cfg *trainConfig) { cfg.runeAligned = enabled }
+}
+
+// WithSeedSymbols carries the k longest symbols of a previous table into the
+// next training run as guaranteed candidates. Each seed enters every round's
+// candidate pool with a small synthetic gain, so a seed the sample still uses
+// has its real counted gain topped up — a head start — while a seed the data
+// has outgrown loses to fresh candidates and is evicted. Retraining this way
+// keeps useful codes stable across table generations, which preserves
+// decoder-array locality in long-running systems. A nil prev or k <= 0 is a
+// no-op.
+func WithSeedSymbols(prev *Table, k int) TrainOption {
+	return func(cfg *trainConfig) {
+		if prev == nil || k <= 0 {
+			return
+		}
+		// Longest first: long symbols carry the most gain history and are the
+		// ones worth anchoring; stable code order breaks ties.
+		seeds := make([]symbol, 0, min(k, int(prev.nSymbols)))
+		for length := uint32(8); length >= 2 && len(seeds) < k; length-- {
+			for i := 0; i < int(prev.nSymbols) && len(seeds) < k; i++ {
+				if prev.symbols[i].length() == length {
+					seeds = append(seeds, prev.symbols[i])
+				}
+			}
+		}
+		cfg.seedSymbols = seeds
+	}
+}
+
+// WithPairMerging controls whether training proposes merged symbol pairs.
+// Disabling it skips pair counting and the pair loop in buildCandidates, so
+// training only keeps boosted single bytes — no multi-byte symbols are ever
+// formed. The result trains several times faster but compresses far worse
+// (single-byte codes at best recode the alphabet), so it is only worth it
+// when a table is needed on a latency-critical path and ratio is secondary.
+// Pair merging is on by default.
+func WithPairMerging(enabled bool) TrainOption {
+	return func(cfg *trainConfig) { cfg.noPairMerging = !enabled }
+}
+
+// WithFullCoverageSample guarantees the training sample contains at least one
+// 512-byte chunk from every non-empty input before the remaining budget is
+// filled randomly. Plain sampling draws chunks proportionally and can miss
+// whole inputs on heterogeneous corpora, leaving their byte patterns
+// unrepresented in the table. The sample can exceed the usual 16KB target by
+// one chunk per input when there are very many inputs; training cost grows
+// accordingly. Sampling stays deterministic.
+func WithFullCoverageSample(enabled bool) TrainOption {
+	return func(cfg *trainConfig) { cfg.fullCoverage = enabled }
+}
+
+// WithTrainingHashSize enlarges the direct-mapped hash table used for 3-8
+// byte symbol lookups during training. The standard table has 2048 slots and
+// drops a candidate when its slot is taken, so on diverse corpora good
+// symbols can be lost to collisions, adding run-to-run table variability
+// across corpus tweaks. size is rounded up to a power of two and ignored when
+// not above the default; 4096 or 8192 are sensible choices. The cost is
+// size*16 bytes of memory during training only — the finalized table rebuilds
+// the standard fixed-size structures and serializes identically either way.
+func WithTrainingHashSize(size int) TrainOption {
+	return func(cfg *trainConfig) { cfg.hashSize = size }
+}
+
+// WithMinSymbolLength stops training from retaining symbols shorter than n
+// bytes, freeing their table slots for longer patterns. Decoding stays
+// correct without short symbols — any byte the table doesn't cover is
+// escaped, at two output bytes instead of one. The useful setting is 2
+// (suppress 1-byte symbols); larger values are honored per round, but since
+// longer symbols grow by pairwise merging of retained shorter ones, n > 2
+// leaves training little to build from and usually an almost empty table.
+// n <= 1 is the default (no minimum); n is capped at 8.
+func WithMinSymbolLength(n int) TrainOption {
+	return func(cfg *trainConfig) { cfg.minSymbolLen = n }
+}
+
+// WithMaxSymbolLength stops training from retaining symbols longer than n
+// bytes (2..8), trading a few long exact patterns for more short ones.
+// Shorter symbols generalize better across varied records: a corpus of short
+// shared fragments can waste slots on 8-byte sequences that rarely recur in
+// full, where twice as many 4-byte symbols would each keep matching. Pair
+// merging still runs; merged candidates over the cap are simply discarded, so
+// the finalized length histogram never exceeds n. Values outside 2..8 keep
+// the default cap of 8.
+func WithMaxSymbolLength(n int) TrainOption {
+	return func(cfg *trainConfig) { cfg.maxSymbolLen = n }
+}
+
+// WithSampleLine sets the length of the contiguous slices the sampler draws,
+// normally 512 bytes. Patterns never straddle slice boundaries in the sample,
+// so for one giant input (a single large JSON document, say) a larger window
+// lets training see correlations the 512-byte default cuts apart; for tiny
+// records the default already captures whole records and nothing changes. The
+// total sample stays near the usual ~16KB target whatever the window, so
+// training cost is unaffected; a window above the sample target is clamped to
+// it. n <= 0 keeps the default.
+func WithSampleLine(n int) TrainOption {
+	return func(cfg *trainConfig) { cfg.sampleLine = n }
+}
+
+// WithSkipIncompressible excludes escape-heavy inputs from training. A
+// preliminary table is trained on the full corpus, and each input's
+// incompressible fraction — bytes covered by escapes or single-byte codes
+// rather than multi-byte symbols (noise trains single-byte symbols, so
+// escapes alone under-count) — is estimated with it. Inputs above threshold
+// (e.g. 0.5 for half the bytes uncompressed) are dropped before the final
+// sample is drawn. This keeps a few binary blobs in an otherwise clean corpus
+// from wasting symbol slots on noise. A threshold <= 0 disables the filter;
+// if every input exceeds the threshold all are kept, since a table has to be
+// trained on something.
+func WithSkipIncompressible(threshold float64) TrainOption {
+	return func(cfg *trainConfig) { cfg.skipThreshold = threshold }
+}
+
 // Train builds and finalizes a compression Table from the provided corpora.
 // It samples inputs, iteratively parses and counts symbol usage, proposes
 // merged symbols, retains top-gain candidates, and finalizes code layout.
-func Train(inputs [][]byte) *Table {
+func Train(inputs [][]byte, opts ...TrainOption) *Table {
+	table, _ := trainTable(nil, inputs, opts)
+	return table
+}
+
+// TrainBytes trains on one contiguous blob with no record boundaries — sugar
+// for Train([][]byte{data}). The sampler draws each 512-byte window at a
+// uniformly random offset across the whole blob, so a single giant input
+// samples as diversely as pre-split records and the ratio comes out
+// comparable to splitting the data into lines by hand (TestTrainBytes pins
+// this). WithSampleLine tunes the window when 512 bytes cuts the blob's
+// structure apart.
+func TrainBytes(data []byte, opts ...TrainOption) *Table {
+	return Train([][]byte{data}, opts...)
+}
+
+// TrainContext is like Train but checks ctx between training rounds and stops
+// early if the context is cancelled. On cancellation it returns ctx.Err()
+// together with a finalized table built from the rounds completed so far; the
+// table is valid for encoding and decoding, just trained on fewer iterations.
+func TrainContext(ctx context.Context, inputs [][]byte, opts ...TrainOption) (*Table, error) {
+	return trainTable(ctx, inputs, opts)
+}
+
+// TrainMulti builds one Table that serves several columns. Each column gets
+// an equal share of the sample budget, so shared vocabulary is learned
+// without a huge column drowning out small ones the way flattening all
+// columns into a single Train call would.
+func TrainMulti(columns [][][]byte, opts ...TrainOption) *Table {
+	var cfg trainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	line := cfg.lineSize()
+	budget := fsstSampleTarget
+	if len(columns) > 0 {
+		budget = max(fsstSampleTarget/len(columns), line)
+	}
+	var sample [][]byte
+	for _, column := range columns {
+		sample = append(sample, makeSampleLined(column, budget, line)...)
+	}
+	table, _ := trainFromSample(nil, sample, cfg)
+	return table
+}
+
+// TrainAuto trains within a wall-clock budget: it runs successive passes with
+// growing sample budgets and keeps the table with the best estimated ratio on
+// a holdout sample, stopping once the budget is spent. The first (cheapest)
+// pass always runs, so a valid finalized table is returned even for a tiny
+// budget.
+func TrainAuto(inputs [][]byte, budget time.Duration, opts ...TrainOption) *Table {
+	var cfg trainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	deadline := time.Now().Add(budget)
+	holdout := makeSample(inputs)
+
+	var (
+		best      *Table
+		bestRatio float64
+	)
+	// Sample budgets: from a quarter of the default up to 4x.
+	for target := fsstSampleTarget / 4; target <= 4*fsstSampleTarget; target *= 2 {
+		table, _ := trainFromSample(nil, makeSampleLined(inputs, target, cfg.lineSize()), cfg)
+		ratio := table.EstimateRatio(holdout)
+		if best == nil || ratio < bestRatio {
+			best, bestRatio = table, ratio
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+	}
+	return best
+}
+
+// TrainWeighted is Train with per-input importance weights: the sample draws
+// from inputs proportionally to weight instead of uniformly, so hot record
+// types shape the vocabulary more than rare ones. Inputs with zero (or
+// negative) weight are excluded from training entirely; if inputs and weights
+// differ in length the extra entries of either are ignored. Sampling stays
+// deterministic for fixed inputs and weights.
+func TrainWeighted(inputs [][]byte, weights []float64, opts ...TrainOption) *Table {
+	var cfg trainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	table, _ := trainFromSample(nil, makeSampleWeighted(inputs, weights, fsstSampleTarget, cfg.lineSize()), cfg)
+	return table
+}
+
+// trainTable runs the shared training loop. ctx may be nil (no cancellation).
+func trainTable(ctx context.Context, inputs [][]byte, opts []TrainOption) (*Table, error) {
+	var cfg trainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.skipThreshold > 0 {
+		inputs = filterIncompressible(inputs, cfg)
+	}
+	if cfg.fullCoverage {
+		return trainFromSample(ctx, makeSampleFullCoverage(inputs, fsstSampleTarget, cfg.lineSize()), cfg)
+	}
+	return trainFromSample(ctx, makeSampleLined(inputs, fsstSampleTarget, cfg.lineSize()), cfg)
+}
+
+// makeSampleFullCoverage draws one deterministic chunk from every non-empty
+// input, then fills the rest of the byte budget with randomly drawn chunks as
+// makeSampleSized does. The coverage pass may push the sample past target
+// when inputs outnumber the chunk budget; that is the point of the guarantee.
+func makeSampleFullCoverage(inputs [][]byte, target, line int) [][]byte {
+	line = min(max(line, 1), target)
+	var total, nonEmpty int
+	for i := range inputs {
+		total += len(inputs[i])
+		if len(inputs[i]) > 0 {
+			nonEmpty++
+		}
+	}
+	if total < target {
+		return inputs
+	}
+
+	maxSz := nonEmpty*line + 2*target
+	var (
+		buf    = make([]byte, maxSz)
+		sample = make([][]byte, 0, nonEmpty)
+		pos    = 0
+	)
+	rng := fsstHash(rngSeed)
+
+	takeChunk := func(idx int) {
+		numChunks := (len(inputs[idx]) + line - 1) / line
+		rng = fsstHash(rng)
+		off := line * int(rng%uint64(numChunks))
+		n := min(len(inputs[idx])-off, line)
+		copy(buf[pos:pos+n], inputs[idx][off:off+n])
+		sample = append(sample, buf[pos:pos+n:pos+n])
+		pos += n
+	}
+
+	// Coverage pass: one chunk from every non-empty input, in input order.
+	for idx := range inputs {
+		if len(inputs[idx]) > 0 {
+			takeChunk(idx)
+		}
+	}
+
+	// Fill the remaining budget with random draws.
+	for pos < target {
+		rng = fsstHash(rng)
+		idx := int(rng % uint64(len(inputs)))
+		for len(inputs[idx]) == 0 {
+			idx = (idx + 1) % len(inputs)
+		}
+		takeChunk(idx)
+	}
+	return sample
+}
+
+// filterIncompressible drops inputs whose non-multi-byte coverage under a
+// preliminary table exceeds cfg.skipThreshold. Each input is probed on a
+// capped prefix so one huge record does not dominate the pre-pass cost.
+// Returns the original slice when nothing survives the filter.
+func filterIncompressible(inputs [][]byte, cfg trainConfig) [][]byte {
+	probeCfg := cfg
+	probeCfg.skipThreshold = 0
+	pre, _ := trainFromSample(nil, makeSample(inputs), probeCfg)
+
+	const probeCap = 8 * fsstSampleLine
+	kept := make([][]byte, 0, len(inputs))
+	for _, input := range inputs {
+		probe := input
+		if len(probe) > probeCap {
+			probe = probe[:probeCap]
+		}
+		report := pre.Coverage([][]byte{probe})
+		if report.EscapeFrac+report.SingleByteFrac <= cfg.skipThreshold {
+			kept = append(kept, input)
+		}
+	}
+	if len(kept) == 0 {
+		return inputs
+	}
+	return kept
+}
+
+// trainFromSample runs the training iterations on an already-built sample.
+func trainFromSample(ctx context.Context, sample [][]byte, cfg trainConfig) (*Table, error) {
+	maxSymbols := fsstMaxSymbols
+	if cfg.extendedCodes {
+		maxSymbols = fsstMaxSymbolsExtended
+	}
+
 	var (
-		sample  = makeSample(inputs)
 		table   = newTable()
 		counter = &counters{}
 		// Reuse allocations across iterations
-		candidates = make(map[[2]uint64]qsym, 512)
-		heap      = make(qsymHeap, 0, fsstMaxSymbols+1)
-		list      = make([]qsym, 0, fsstMaxSymbols)
+		candidates = newCandidateSet(512)
+		heap      = make(qsymHeap, 0, maxSymbols+1)
+		list      = make([]qsym, 0, maxSymbols)
 	)
+	table.extended = cfg.extendedCodes
+	if cfg.hashSize > fsstHashTabSize {
+		size := 1
+		for size < cfg.hashSize {
+			size *= 2
+		}
+		table.trainHash = make([]symbol, size)
+		for i := range table.trainHash {
+			table.trainHash[i].icl = fsstICLFree
+		}
+	}
 
 	for frac := 8; ; frac += 30 {
+		if ctx != nil {
+			if err := ctx.Err(); err != nil {
+				table.finalize()
+				return table, err
+			}
+		}
+		if cfg.report != nil {
+			cfg.report.Rounds++
+		}
 		*counter = counters{}
-		compressCount(table, counter, sample, frac)
-		buildCandidates(table, counter, frac, candidates, &heap, &list)
+		compressCount(table, counter, sample, frac, !cfg.noPairMerging)
+		buildCandidates(table, counter, frac, maxSymbols, cfg, candidates, &heap, &list)
+		if cfg.observer != nil {
+			cfg.observer.OnRound(frac, int(table.nSymbols))
+		}
 		if frac >= 128 {
 			break
 		}
 	}
+	if table.trainHash != nil {
+		// The enlarged hash removed insertion backpressure, so the selection
+		// may hold 3-8 byte symbols that collide in the encoder's fixed-size
+		// tables and would be unreachable — wasted codes. Re-add the symbols
+		// (already in gain order) under the standard constraint, dropping the
+		// ones that no longer fit.
+		kept := make([]symbol, 0, table.nSymbols)
+		for i := range int(table.nSymbols) {
+			kept = append(kept, table.symbols[int(fsstCodeBase)+i])
+		}
+		table.clearSymbols()
+		table.trainHash = nil
+		for _, sym := range kept {
+			table.addSymbol(sym)
+		}
+	}
 	table.finalize()
-	return table
+	table.asciiOnly = sampleIsASCII(sample)
+	if cfg.macros && !cfg.extendedCodes {
+		table.buildMacros(sample)
+	}
+	if cfg.backrefWindow > 0 && !cfg.extendedCodes && !cfg.macros &&
+		int(table.nSymbols) < fsstMaxSymbols {
+		table.backrefWindow = uint8(min(cfg.backrefWindow, fsstBackrefMaxWindow))
+	}
+	table.observer = cfg.observer
+	return table, nil
+}
+
+// sampleIsASCII reports whether no sampled byte has the high bit set. Tables
+// trained on such samples get the ASCII encode fast path.
+func sampleIsASCII(sample [][]byte) bool {
+	for i := range sample {
+		for _, b := range sample[i] {
+			if b&0x80 != 0 {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 // findNextSymbolFast returns the best match at data[position:] using the
 // current Table: prefer 3–8 byte hash hits, then unique 2-byte short codes,
 // otherwise fall back to single-byte. Returns code and matched length.
 func findNextSymbolFast(t *Table, data []byte, position int) (code uint16, advance int) {
+	tab, idxMask := t.trainHashTab()
 	var (
 		word       = fsstUnalignedLoad(data[position:])
 		prefix24   = word & fsstMask24
-		hashIndex  = fsstHash(prefix24) & (fsstHashTabSize - 1)
-		hashSymbol = t.hashTab[hashIndex]
+		hashIndex  = fsstHash(prefix24) & idxMask
+		hashSymbol = tab[hashIndex]
 		shortCode  = t.shortCodes[uint16(word&fsstMask16)] & fsstCodeMask
 		symbolMask = ^uint64(0) >> hashSymbol.ignoredBits()
 		maskedWord = word & symbolMask
@@ -66,9 +492,10 @@ func findNextSymbolFast(t *Table, data []byte, position int) (code uint16, advan
 }
 
 // compressCount walks the sample as the encoder would with the current Table,
-// incrementing single counts and (in early rounds) pair counts to drive
-// candidate selection in the subsequent build step.
-func compressCount(t *Table, c *counters, sample [][]byte, frac int) {
+// incrementing single counts and (in early rounds, unless pair merging is
+// disabled) pair counts to drive candidate selection in the subsequent build
+// step.
+func compressCount(t *Table, c *counters, sample [][]byte, frac int, countPairs bool) {
 	for i := range sample {
 		if frac < 128 && int(fsstHash(uint64(i))&fsstSampleMask) > frac {
 			continue
@@ -101,7 +528,7 @@ func compressCount(t *Table, c *counters, sample [][]byte, frac int) {
 				next = t.findLongestSymbol(newSymbolFromBytes(sample[i][pos:min(pos+8, end)]))
 				pos += int(t.symbols[next].length())
 			}
-			if frac < 128 {
+			if frac < 128 && countPairs {
 				n := pos - start
 				c.incPair(uint32(cur), uint32(next))
 				if n > 1 {
@@ -118,22 +545,29 @@ type qsym struct {
 	gain   uint32
 }
 
-// qsymHeap is a min-heap of qsym based on gain (with tiebreak on symbol.val).
+// candidateLess is a total order on candidates: ascending gain, ties broken
+// by larger symbol value, then by larger length. Distinct candidates always
+// differ in (val, length), so no two candidates ever compare equal — this
+// makes top-K selection independent of map iteration order.
+func candidateLess(a, b qsym) bool {
+	if a.gain != b.gain {
+		return a.gain < b.gain
+	}
+	if a.symbol.val != b.symbol.val {
+		return a.symbol.val > b.symbol.val
+	}
+	return a.symbol.length() > b.symbol.length()
+}
+
+// qsymHeap is a min-heap of qsym ordered by candidateLess.
 // We use a min-heap to maintain top-K elements efficiently.
 type qsymHeap []qsym
 
 // Len implements heap.Interface and returns the number of elements.
 func (h qsymHeap) Len() int { return len(h) }
 
-// Less implements heap.Interface ordering by ascending gain, breaking ties
-// by larger symbol value to keep selection deterministic.
-func (h qsymHeap) Less(i, j int) bool {
-	// Min-heap: smaller gain at root (or larger val for tiebreak)
-	if h[i].gain != h[j].gain {
-		return h[i].gain < h[j].gain
-	}
-	return h[i].symbol.val > h[j].symbol.val
-}
+// Less implements heap.Interface via candidateLess.
+func (h qsymHeap) Less(i, j int) bool { return candidateLess(h[i], h[j]) }
 
 // Swap implements heap.Interface swap.
 func (h qsymHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
@@ -150,14 +584,97 @@ func (h *qsymHeap) Pop() any {
 	return x
 }
 
+// candidateSet is a flat open-addressing hash table of candidates keyed by
+// (val, length). It replaces a Go map on the training hot path: slots live in
+// one slab with no per-entry allocation, clearing is a memclr, and linear
+// probing keeps lookups cache-friendly. Dedup semantics match the old map:
+// adding an existing key sums the gains.
+type candidateSet struct {
+	slots []candidateSlot
+	n     int
+}
+
+type candidateSlot struct {
+	cand qsym
+	used bool
+}
+
+// newCandidateSet sizes the slot slab at the next power of two holding
+// expected entries below the 3/4 load factor.
+func newCandidateSet(expected int) *candidateSet {
+	size := 8
+	for 3*size < 4*expected {
+		size <<= 1
+	}
+	return &candidateSet{slots: make([]candidateSlot, size)}
+}
+
+// clear empties the set while keeping the slab for reuse.
+func (s *candidateSet) clear() {
+	clear(s.slots)
+	s.n = 0
+}
+
+// add inserts the candidate, summing gains when a candidate with the same
+// (val, length) already exists.
+func (s *candidateSet) add(sym symbol, gain uint32) {
+	if 4*(s.n+1) > 3*len(s.slots) {
+		s.grow()
+	}
+	mask := uint64(len(s.slots) - 1)
+	idx := (fsstHash(sym.val) ^ uint64(sym.length())) & mask
+	for {
+		slot := &s.slots[idx]
+		if !slot.used {
+			slot.cand = qsym{symbol: sym, gain: gain}
+			slot.used = true
+			s.n++
+			return
+		}
+		if slot.cand.symbol.val == sym.val && slot.cand.symbol.length() == sym.length() {
+			slot.cand.gain += gain
+			return
+		}
+		idx = (idx + 1) & mask
+	}
+}
+
+// grow doubles the slab and reinserts every occupied slot.
+func (s *candidateSet) grow() {
+	old := s.slots
+	s.slots = make([]candidateSlot, 2*len(old))
+	s.n = 0
+	for i := range old {
+		if old[i].used {
+			s.add(old[i].cand.symbol, old[i].cand.gain)
+		}
+	}
+}
+
 // buildCandidates creates symbol candidates from current counters. It boosts
 // single bytes, considers merged pairs (except in the last round), scores by
 // gain≈frequency×length, keeps top-K via a min-heap, and updates the Table.
 // Reuses provided allocations to reduce GC pressure.
-func buildCandidates(t *Table, c *counters, frac int, candidates map[[2]uint64]qsym, h *qsymHeap, list *[]qsym) {
-	// Clear candidates map for reuse (clear() is more efficient than delete loop)
-	clear(candidates)
+func buildCandidates(t *Table, c *counters, frac, maxSymbols int, cfg trainConfig, candidates *candidateSet, h *qsymHeap, list *[]qsym) {
+	candidates.clear()
 	minCount := max((minCountNumerator*frac)/minCountDenominator, 1)
+	minLen := uint32(1)
+	if cfg.minSymbolLen > 1 {
+		minLen = uint32(min(cfg.minSymbolLen, 8))
+	}
+	maxLen := uint32(8)
+	if cfg.maxSymbolLen >= 2 && cfg.maxSymbolLen < 8 {
+		maxLen = uint32(cfg.maxSymbolLen)
+	}
+
+	// With WithRuneAlignedSymbols, gains of symbols that straddle UTF-8 rune
+	// boundaries are quartered so aligned alternatives win ties.
+	adjust := func(sym symbol, gain uint32) uint32 {
+		if cfg.runeAligned && !symbolRuneAligned(sym) {
+			return gain / 4
+		}
+		return gain
+	}
 
 	for code := uint32(0); code < fsstCodeBase+uint32(t.nSymbols); code++ {
 		count := c.nextSingle(&code)
@@ -165,23 +682,21 @@ func buildCandidates(t *Table, c *counters, frac int, candidates map[[2]uint64]q
 			continue
 		}
 		sym := t.symbols[code]
+		if sym.length() < minLen || sym.length() > maxLen {
+			continue
+		}
 		weight := uint64(count)
 		if sym.length() == 1 {
 			weight *= singleByteBoost
 		}
 		if int(weight) >= minCount {
-			key := [2]uint64{sym.val, uint64(sym.length())}
-			gain := uint32(weight) * uint32(sym.length())
-			if existing, ok := candidates[key]; ok {
-				gain += existing.gain
-			}
-			candidates[key] = qsym{symbol: sym, gain: gain}
+			candidates.add(sym, adjust(sym, uint32(weight)*uint32(sym.length())))
 		}
 
 	}
 
 	// Process pairs using sparse list (much faster than nested iteration)
-	if frac < 128 {
+	if frac < 128 && !cfg.noPairMerging {
 		for _, pair := range c.pairList {
 			code := pair[0]
 			code2 := pair[1]
@@ -206,31 +721,65 @@ func buildCandidates(t *Table, c *counters, frac int, candidates map[[2]uint64]q
 			}
 
 			sym := t.symbols[code]
-			if sym.length() == 8 {
+			if sym.length() >= maxLen {
 				continue
 			}
 
 			sym2 := t.symbols[code2]
 			merged := fsstConcat(sym, sym2)
-			key := [2]uint64{merged.val, uint64(merged.length())}
-			gain := uint32(count2) * uint32(merged.length())
-			if existing, ok := candidates[key]; ok {
-				gain += existing.gain
+			if merged.length() < minLen || merged.length() > maxLen {
+				continue
 			}
-			candidates[key] = qsym{symbol: merged, gain: gain}
+			candidates.add(merged, adjust(merged, uint32(count2)*uint32(merged.length())))
+		}
+	}
+
+	// Seeded symbols from a previous table compete in the merging rounds; add
+	// sums gains, so a seed the sample still uses keeps its counted gain plus
+	// this small floor. The final round rescores the table on counts alone, so
+	// a seed the data has outgrown is evicted there like any unused symbol.
+	if frac < 128 {
+		for _, sym := range cfg.seedSymbols {
+			candidates.add(sym, adjust(sym, uint32(minCount)))
+		}
+	}
+
+	selectTopCandidates(candidates, maxSymbols, h, list)
+
+	if cfg.report != nil {
+		// Ranking keeps only the top maxSymbols; everything else was dropped
+		// for capacity before the table was touched.
+		if extra := candidates.n - len(*list); extra > 0 {
+			cfg.report.CapacityDropped += extra
 		}
 	}
 
-	// Use min-heap to efficiently select top fsstMaxSymbols candidates
-	// This is O(n log k) instead of O(n log n) where k=255, n=candidates
+	t.clearSymbols()
+	for i := 0; i < len(*list) && int(t.nSymbols) < maxSymbols; i++ {
+		if !t.addSymbol((*list)[i].symbol) && cfg.report != nil {
+			length := (*list)[i].symbol.length()
+			cfg.report.CollisionDropped++
+			cfg.report.CollisionsByLength[length-1]++
+		}
+	}
+}
+
+// selectTopCandidates fills list with the top maxSymbols candidates by gain
+// in descending order, reusing the provided heap and list allocations.
+func selectTopCandidates(candidates *candidateSet, maxSymbols int, h *qsymHeap, list *[]qsym) {
+	// Use min-heap to efficiently select top maxSymbols candidates
+	// This is O(n log k) instead of O(n log n) where k=maxSymbols, n=candidates
 	*h = (*h)[:0] // Reuse heap, clear contents
 	heap.Init(h)
 
-	for _, candidate := range candidates {
-		if len(*h) < fsstMaxSymbols {
+	for i := range candidates.slots {
+		if !candidates.slots[i].used {
+			continue
+		}
+		candidate := candidates.slots[i].cand
+		if len(*h) < maxSymbols {
 			heap.Push(h, candidate)
-		} else if candidate.gain > (*h)[0].gain ||
-			(candidate.gain == (*h)[0].gain && candidate.symbol.val < (*h)[0].symbol.val) {
+		} else if candidateLess((*h)[0], candidate) {
 			// Replace minimum with this better candidate
 			heap.Pop(h)
 			heap.Push(h, candidate)
@@ -252,43 +801,171 @@ func buildCandidates(t *Table, c *counters, frac int, candidates map[[2]uint64]q
 	for i, j := 0, len(*list)-1; i < j; i, j = i+1, j-1 {
 		(*list)[i], (*list)[j] = (*list)[j], (*list)[i]
 	}
+}
 
-	t.clearSymbols()
-	for i := 0; i < len(*list) && int(t.nSymbols) < fsstMaxSymbols; i++ {
-		t.addSymbol((*list)[i].symbol)
+// TrainFromCounts builds a Table from externally aggregated frequency counts
+// instead of raw text: singles maps substrings (1-8 bytes) to their observed
+// counts, pairs maps adjacent substring pairs to co-occurrence counts (the
+// pair is merged into one candidate symbol, truncated at 8 bytes). Scoring
+// matches the regular training rounds (gain ≈ count × length with the
+// single-byte boost). Sparse or empty counts still yield a valid table; bytes
+// without symbols fall back to escapes.
+func TrainFromCounts(singles map[string]uint32, pairs map[[2]string]uint32) *Table {
+	table := newTable()
+	candidates := newCandidateSet(len(singles) + len(pairs))
+
+	addCandidate := func(sym symbol, count uint32) {
+		weight := uint64(count)
+		if sym.length() == 1 {
+			weight *= singleByteBoost
+		}
+		candidates.add(sym, uint32(weight)*uint32(sym.length()))
 	}
+
+	for s, count := range singles {
+		if len(s) == 0 || len(s) > 8 || count == 0 {
+			continue
+		}
+		addCandidate(newSymbolFromBytes([]byte(s)), count)
+	}
+	for pair, count := range pairs {
+		if len(pair[0]) == 0 || len(pair[1]) == 0 || len(pair[0]) >= 8 || count == 0 {
+			continue
+		}
+		merged := fsstConcat(newSymbolFromBytes([]byte(pair[0])), newSymbolFromBytes([]byte(pair[1])))
+		addCandidate(merged, count)
+	}
+
+	var (
+		h    = make(qsymHeap, 0, fsstMaxSymbols+1)
+		list = make([]qsym, 0, fsstMaxSymbols)
+	)
+	selectTopCandidates(candidates, fsstMaxSymbols, &h, &list)
+	for i := 0; i < len(list) && int(table.nSymbols) < fsstMaxSymbols; i++ {
+		table.addSymbol(list[i].symbol)
+	}
+	table.finalize()
+	return table
 }
 
 // TrainStrings converts []string to [][]byte and calls Train.
-func TrainStrings(inputs []string) *Table {
+func TrainStrings(inputs []string, opts ...TrainOption) *Table {
 	bytes := make([][]byte, len(inputs))
 	for i := range inputs {
 		bytes[i] = unsafe.Slice(unsafe.StringData(inputs[i]), len(inputs[i]))
 	}
-	return Train(bytes)
+	return Train(bytes, opts...)
 }
 
 // makeSample assembles a ~16KB deterministic pseudo-random sample composed of
 // 512-byte slices from the inputs to keep training fast yet representative.
 func makeSample(inputs [][]byte) [][]byte {
+	return makeSampleSized(inputs, fsstSampleTarget)
+}
+
+// makeSampleWeighted is makeSampleSized drawing input indexes proportionally
+// to weight rather than uniformly. Zero- and negative-weight inputs never
+// contribute to the sample.
+func makeSampleWeighted(inputs [][]byte, weights []float64, target, line int) [][]byte {
+	line = min(max(line, 1), target)
+	n := min(len(inputs), len(weights))
+
+	// Cumulative weights over eligible inputs for proportional selection.
+	var (
+		eligible []int
+		cum      []float64
+		totalW   float64
+		total    int
+	)
+	for i := 0; i < n; i++ {
+		if weights[i] <= 0 || len(inputs[i]) == 0 {
+			continue
+		}
+		totalW += weights[i]
+		eligible = append(eligible, i)
+		cum = append(cum, totalW)
+		total += len(inputs[i])
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+	if total < target {
+		sample := make([][]byte, 0, len(eligible))
+		for _, idx := range eligible {
+			sample = append(sample, inputs[idx])
+		}
+		return sample
+	}
+
+	maxSz := 2 * target
+	var (
+		buf    = make([]byte, maxSz)
+		sample = make([][]byte, 0, len(eligible))
+		pos    = 0
+	)
+
+	rng := fsstHash(rngSeed)
+
+	for pos < maxSz {
+		rng = fsstHash(rng)
+		// Map the raw hash onto [0, totalW) and pick the first input whose
+		// cumulative weight exceeds it.
+		r := float64(rng>>11) / float64(1<<53) * totalW
+		k := sort.SearchFloat64s(cum, r)
+		if k == len(cum) {
+			k = len(cum) - 1
+		}
+		idx := eligible[k]
+
+		numChunks := (len(inputs[idx]) + line - 1) / line
+		rng = fsstHash(rng)
+		off := line * int(rng%uint64(numChunks))
+
+		n := min(len(inputs[idx])-off, line)
+		if pos+n > maxSz {
+			break
+		}
+		copy(buf[pos:pos+n], inputs[idx][off:off+n])
+		sample = append(sample, buf[pos:pos+n:pos+n])
+		pos += n
+
+		if pos >= target {
+			break
+		}
+	}
+	return sample
+}
+
+// makeSampleSized is makeSample with an explicit byte budget, used when a
+// caller (e.g. TrainMulti) splits the overall budget across input groups.
+func makeSampleSized(inputs [][]byte, target int) [][]byte {
+	return makeSampleLined(inputs, target, fsstSampleLine)
+}
+
+// makeSampleLined is makeSampleSized with an explicit slice length, used when
+// WithSampleLine tunes the window to the data's structure. line is clamped to
+// [1, target] so the budget always fits at least one slice.
+func makeSampleLined(inputs [][]byte, target, line int) [][]byte {
+	line = min(max(line, 1), target)
 	var total int
 	for i := range inputs {
 		total += len(inputs[i])
 	}
 
-	if total < fsstSampleTarget {
+	if total < target {
 		return inputs
 	}
 
+	maxSz := 2 * target
 	var (
-		buf    = make([]byte, fsstSampleMaxSz)
+		buf    = make([]byte, maxSz)
 		sample = make([][]byte, 0, len(inputs))
 		pos    = 0
 	)
 
 	rng := fsstHash(rngSeed)
 
-	for pos < fsstSampleMaxSz {
+	for pos < maxSz {
 		rng = fsstHash(rng)
 		idx := int(rng % uint64(len(inputs)))
 
@@ -296,19 +973,19 @@ func makeSample(inputs [][]byte) [][]byte {
 			idx = (idx + 1) % len(inputs)
 		}
 
-		numChunks := (len(inputs[idx]) + fsstSampleLine - 1) / fsstSampleLine
+		numChunks := (len(inputs[idx]) + line - 1) / line
 		rng = fsstHash(rng)
-		off := fsstSampleLine * int(rng%uint64(numChunks))
+		off := line * int(rng%uint64(numChunks))
 
-		n := min(len(inputs[idx])-off, fsstSampleLine)
-		if pos+n > fsstSampleMaxSz {
+		n := min(len(inputs[idx])-off, line)
+		if pos+n > maxSz {
 			break
 		}
 		copy(buf[pos:pos+n], inputs[idx][off:off+n])
 		sample = append(sample, buf[pos:pos+n:pos+n])
 		pos += n
 
-		if pos >= fsstSampleTarget {
+		if pos >= target {
 			break
 		}
 	}
@@ -0,0 +1,36 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestTrainAuto(t *testing.T) {
+	inputs := [][]byte{
+		bytes.Repeat([]byte("structured log line with fields "), 100),
+		bytes.Repeat([]byte("another structured log line "), 100),
+	}
+
+	// Generous budget: should find a compressing table.
+	tbl := TrainAuto(inputs, 200*time.Millisecond)
+	if tbl == nil {
+		t.Fatalf("TrainAuto returned nil")
+	}
+	for i := range inputs {
+		got := tbl.DecodeAll(tbl.EncodeAll(inputs[i]))
+		if !bytes.Equal(got, inputs[i]) {
+			t.Fatalf("TrainAuto roundtrip mismatch for input %d", i)
+		}
+	}
+
+	// Zero budget still returns a valid finalized table.
+	tbl = TrainAuto(inputs, 0)
+	if tbl == nil {
+		t.Fatalf("TrainAuto with zero budget returned nil")
+	}
+	got := tbl.DecodeAll(tbl.EncodeAll(inputs[0]))
+	if !bytes.Equal(got, inputs[0]) {
+		t.Fatalf("zero-budget table roundtrip mismatch")
+	}
+}
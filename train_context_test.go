@@ -0,0 +1,47 @@
+package fsst
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestTrainContext(t *testing.T) {
+	inputs := [][]byte{
+		[]byte("the quick brown fox jumps over the lazy dog"),
+		[]byte("pack my box with five dozen liquor jugs"),
+	}
+
+	// Uncancelled context trains identically to Train.
+	tbl, err := TrainContext(context.Background(), inputs)
+	if err != nil {
+		t.Fatalf("TrainContext: %v", err)
+	}
+	var b1, b2 bytes.Buffer
+	if _, err := tbl.WriteTo(&b1); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Train(inputs).WriteTo(&b2); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+		t.Fatalf("TrainContext differs from Train on same inputs")
+	}
+}
+
+func TestTrainContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	input := []byte("hello world, hello there")
+	tbl, err := TrainContext(ctx, [][]byte{input})
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	// Partially-trained table must still roundtrip.
+	comp := tbl.EncodeAll(input)
+	got := tbl.DecodeAll(comp)
+	if !bytes.Equal(got, input) {
+		t.Fatalf("partial table roundtrip mismatch")
+	}
+}
@@ -0,0 +1,43 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTrainFromCounts(t *testing.T) {
+	singles := map[string]uint32{
+		"hello ": 1000,
+		"world":  800,
+		"h":      50,
+		"e":      40,
+	}
+	pairs := map[[2]string]uint32{
+		{"hello ", "wo"}: 300,
+	}
+	tbl := TrainFromCounts(singles, pairs)
+
+	input := []byte("hello world hello world hello ")
+	comp := tbl.EncodeAll(input)
+	got := tbl.DecodeAll(comp)
+	if !bytes.Equal(got, input) {
+		t.Fatalf("TrainFromCounts roundtrip mismatch")
+	}
+	if len(comp) >= len(input) {
+		t.Fatalf("counted vocabulary did not compress: %d >= %d", len(comp), len(input))
+	}
+}
+
+func TestTrainFromCountsSparse(t *testing.T) {
+	// Empty and degenerate counts still yield a usable table.
+	for _, tbl := range []*Table{
+		TrainFromCounts(nil, nil),
+		TrainFromCounts(map[string]uint32{"": 10, "toolongsymbol": 5}, nil),
+	} {
+		input := []byte("uncovered input text")
+		got := tbl.DecodeAll(tbl.EncodeAll(input))
+		if !bytes.Equal(got, input) {
+			t.Fatalf("sparse-count table roundtrip mismatch")
+		}
+	}
+}
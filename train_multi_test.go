@@ -0,0 +1,47 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTrainMulti(t *testing.T) {
+	// A huge column and a tiny one with disjoint vocabularies.
+	big := make([][]byte, 0, 2000)
+	for range 2000 {
+		big = append(big, []byte("common_big_column_token common_big_column_token"))
+	}
+	small := [][]byte{
+		[]byte("rare_small_column_value"),
+		[]byte("rare_small_column_value"),
+	}
+
+	tbl := TrainMulti([][][]byte{big, small})
+
+	// Both columns must roundtrip through the shared table.
+	for _, column := range [][][]byte{big[:1], small} {
+		for i := range column {
+			comp := tbl.EncodeAll(column[i])
+			got := tbl.DecodeAll(comp)
+			if !bytes.Equal(got, column[i]) {
+				t.Fatalf("TrainMulti roundtrip mismatch: %q", column[i])
+			}
+		}
+	}
+
+	// The small column's vocabulary should not be drowned out: its data must
+	// actually compress, not degrade to all-escape output.
+	smallComp := tbl.EncodeAll(small[0])
+	if len(smallComp) >= len(small[0]) {
+		t.Fatalf("small column not compressed: %d >= %d", len(smallComp), len(small[0]))
+	}
+}
+
+func TestTrainMultiEmpty(t *testing.T) {
+	tbl := TrainMulti(nil)
+	input := []byte("fallback on untrained table")
+	got := tbl.DecodeAll(tbl.EncodeAll(input))
+	if !bytes.Equal(got, input) {
+		t.Fatalf("empty TrainMulti table roundtrip mismatch")
+	}
+}
@@ -30,6 +30,59 @@ func TestTrainDeterministic(t *testing.T) {
 	}
 }
 
+// TestCandidateSetDedup verifies the flat candidate table sums gains for
+// repeated (val, length) keys and survives growth without losing entries.
+func TestCandidateSetDedup(t *testing.T) {
+	set := newCandidateSet(4)
+	sym := newSymbolFromBytes([]byte("ab"))
+	set.add(sym, 10)
+	set.add(sym, 5)
+
+	// Force several growth rounds with distinct symbols.
+	for i := 0; i < 100; i++ {
+		set.add(newSymbolFromBytes([]byte{byte(i), byte(i >> 4), 'x'}), uint32(i+1))
+	}
+
+	found := 0
+	for i := range set.slots {
+		if !set.slots[i].used {
+			continue
+		}
+		found++
+		c := set.slots[i].cand
+		if c.symbol.val == sym.val && c.symbol.length() == sym.length() && c.gain != 15 {
+			t.Fatalf("gain for duplicate key = %d, want 15", c.gain)
+		}
+	}
+	if found != 101 || set.n != 101 {
+		t.Fatalf("set holds %d entries (n=%d), want 101", found, set.n)
+	}
+}
+
+// TestTrainDeterministicStress repeats training many times on input designed
+// to produce equal-gain candidates (including val ties via zero bytes) and
+// asserts every run yields the identical table.
+func TestTrainDeterministicStress(t *testing.T) {
+	inputs := [][]byte{
+		[]byte("ab\x00ab\x00ab\x00cd\x00cd\x00cd\x00"),
+		[]byte("the quick brown fox jumps over the lazy dog"),
+		bytes.Repeat([]byte{0, 'x', 0, 'y'}, 32),
+	}
+	var want bytes.Buffer
+	if _, err := Train(inputs).WriteTo(&want); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		var got bytes.Buffer
+		if _, err := Train(inputs).WriteTo(&got); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Fatalf("training run %d produced a different table", i)
+		}
+	}
+}
+
 func TestTrainEncodeDecode(t *testing.T) {
 	inputs := [][]byte{
 		[]byte("hello world"),
@@ -435,11 +488,19 @@ func FuzzDecoder(f *testing.F) {
 			f.Add(comp)
 		}
 	}
+	// Edge cases the random corpus rarely hits: empty input, a lone escape
+	// byte, and an escape as the final byte of an otherwise valid stream.
+	f.Add([]byte{})
+	f.Add([]byte{fsstEscapeCode})
+	f.Add([]byte{fsstEscapeCode, 'x', fsstEscapeCode})
+	f.Add([]byte{0, 1, 2, fsstEscapeCode})
 	f.Fuzz(func(t *testing.T, compressedData []byte) {
 		// Create a simple table
 		tbl := Train([][]byte{[]byte("test")})
 		// Should never panic on any compressed data
 		_ = tbl.DecodeAll(compressedData)
+		// SafeDecode guarantees (nil-error XOR anomaly), never a panic
+		_, _ = tbl.SafeDecode(compressedData)
 	})
 }
 
@@ -0,0 +1,64 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTrainWeightedDeterministic(t *testing.T) {
+	inputs := [][]byte{
+		[]byte(strings.Repeat("hot record type ", 100)),
+		[]byte(strings.Repeat("cold record type ", 100)),
+	}
+	weights := []float64{10, 1}
+
+	tbl1 := TrainWeighted(inputs, weights)
+	tbl2 := TrainWeighted(inputs, weights)
+	var b1, b2 bytes.Buffer
+	if _, err := tbl1.WriteTo(&b1); err != nil {
+		t.Fatalf("write1: %v", err)
+	}
+	if _, err := tbl2.WriteTo(&b2); err != nil {
+		t.Fatalf("write2: %v", err)
+	}
+	if !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+		t.Fatalf("weighted training is not deterministic")
+	}
+}
+
+func TestTrainWeightedFavorsHotInputs(t *testing.T) {
+	// Two disjoint vocabularies; the heavily weighted one should compress
+	// better with the weighted table than with reversed weights.
+	hot := []byte(strings.Repeat("alpha beta gamma delta ", 800))
+	cold := []byte(strings.Repeat("0123456789!@#$%^&*()_+ ", 800))
+	inputs := [][]byte{hot, cold}
+
+	favorHot := TrainWeighted(inputs, []float64{100, 1})
+	favorCold := TrainWeighted(inputs, []float64{1, 100})
+
+	hotWithHot := favorHot.EstimateRatio([][]byte{hot})
+	hotWithCold := favorCold.EstimateRatio([][]byte{hot})
+	if hotWithHot >= hotWithCold {
+		t.Errorf("hot-favoring table ratio %f should beat cold-favoring %f on hot data",
+			hotWithHot, hotWithCold)
+	}
+}
+
+func TestTrainWeightedZeroExcludes(t *testing.T) {
+	poison := []byte(strings.Repeat("ZZZZZZZZ", 600))
+	useful := []byte(strings.Repeat("payload ", 600))
+	tbl := TrainWeighted([][]byte{poison, useful}, []float64{0, 1})
+
+	// Excluded input contributes no symbols: everything in it escapes or
+	// falls back to single bytes learned elsewhere.
+	for _, sym := range tbl.Symbols() {
+		if len(sym) > 1 && bytes.Contains(poison[:8], sym) {
+			t.Fatalf("symbol %q learned from zero-weight input", sym)
+		}
+	}
+	comp := tbl.Encode(nil, useful)
+	if got := tbl.Decode(nil, comp); !bytes.Equal(got, useful) {
+		t.Fatalf("roundtrip mismatch")
+	}
+}
@@ -0,0 +1,45 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestTrainBytes(t *testing.T) {
+	data, err := os.ReadFile("testdata/logs_apache_2k.log")
+	if err != nil {
+		t.Fatalf("read corpus: %v", err)
+	}
+
+	blob := TrainBytes(data)
+	if !blob.Equal(Train([][]byte{data})) {
+		t.Fatal("TrainBytes differs from Train on the wrapped blob")
+	}
+	comp := blob.Encode(nil, data)
+	if got := blob.Decode(nil, comp); !bytes.Equal(got, data) {
+		t.Fatal("roundtrip failed")
+	}
+
+	// Sampling spreads across the whole blob, so the ratio stays comparable
+	// to training on hand-split lines. Record-aligned samples keep a small
+	// edge (windows at arbitrary offsets cut tokens), so allow 25%.
+	lines := bytes.Split(data, []byte{'\n'})
+	lined := Train(lines)
+	blobLen := len(comp)
+	linedLen := len(lined.Encode(nil, data))
+	if float64(blobLen) > 1.25*float64(linedLen) {
+		t.Errorf("blob-trained table encodes to %d bytes, line-trained to %d; sampling clustered", blobLen, linedLen)
+	}
+
+	// The sampler really does draw from distinct windows: a blob whose halves
+	// use disjoint vocabularies must cover both with multi-byte symbols.
+	half := bytes.Repeat([]byte("alpha beta gamma delta "), 2000)
+	other := bytes.Repeat([]byte("0123-4567-89AB-CDEF/"), 2000)
+	tbl := TrainBytes(append(append([]byte{}, half...), other...))
+	for name, part := range map[string][]byte{"first": half, "second": other} {
+		if cov := tbl.Coverage([][]byte{part}); cov.MultiByteFrac < 0.5 {
+			t.Errorf("%s half of the blob only %.0f%% covered by multi-byte symbols", name, 100*cov.MultiByteFrac)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package fsst
+
+// TrainCost describes how much work a Train call over some inputs will do,
+// computed without training. Schedulers use it to budget CPU for a worker
+// pool before committing to the real run.
+type TrainCost struct {
+	// SampleBytes is the size of the training sample makeSample will draw:
+	// the full input when it fits the sample target, the capped sample
+	// otherwise.
+	SampleBytes int
+	// Rounds is the number of learning iterations Train performs.
+	Rounds int
+	// Ops is a rough operation count, SampleBytes times Rounds. It tracks
+	// wall time to within a constant factor across corpora.
+	Ops int
+	// NonEmptyInputs counts the inputs that can contribute to the sample.
+	NonEmptyInputs int
+	// TotalBytes is the combined length of all inputs.
+	TotalBytes int
+}
+
+// EstimateTrainCost reports the training cost for inputs without building a
+// sample or touching their bytes. SampleBytes is exact when the inputs fit
+// within the sample target and within one sample line of it otherwise, since
+// sampling stops at the first chunk boundary past the target.
+func EstimateTrainCost(inputs [][]byte) TrainCost {
+	var cost TrainCost
+	for i := range inputs {
+		cost.TotalBytes += len(inputs[i])
+		if len(inputs[i]) > 0 {
+			cost.NonEmptyInputs++
+		}
+	}
+
+	cost.SampleBytes = min(cost.TotalBytes, fsstSampleTarget)
+
+	// Mirrors the round schedule in Train (frac = 8, 38, ..., 128).
+	for frac := 8; ; frac += 30 {
+		cost.Rounds++
+		if frac >= 128 {
+			break
+		}
+	}
+
+	cost.Ops = cost.SampleBytes * cost.Rounds
+	return cost
+}
@@ -0,0 +1,36 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEstimateTrainCost(t *testing.T) {
+	small := [][]byte{[]byte("tiny"), nil, []byte("sample")}
+	cost := EstimateTrainCost(small)
+	if cost.TotalBytes != 10 || cost.NonEmptyInputs != 2 {
+		t.Fatalf("small inputs: got %+v", cost)
+	}
+	if cost.SampleBytes != 10 {
+		t.Fatalf("inputs below the target must be sampled whole, got %d", cost.SampleBytes)
+	}
+	if cost.Rounds != 5 || cost.Ops != cost.SampleBytes*cost.Rounds {
+		t.Fatalf("rounds/ops mismatch: %+v", cost)
+	}
+
+	// Large inputs are capped at the sample target; the real sample lands
+	// within one sample line of the estimate.
+	big := [][]byte{bytes.Repeat([]byte("0123456789abcdef"), 1<<14)}
+	cost = EstimateTrainCost(big)
+	if cost.SampleBytes != fsstSampleTarget {
+		t.Fatalf("large inputs: sample estimate %d, want %d", cost.SampleBytes, fsstSampleTarget)
+	}
+	var actual int
+	for _, chunk := range makeSample(big) {
+		actual += len(chunk)
+	}
+	if diff := actual - cost.SampleBytes; diff < -fsstSampleLine || diff > fsstSampleLine {
+		t.Fatalf("estimate %d off from actual sample %d by more than one line",
+			cost.SampleBytes, actual)
+	}
+}
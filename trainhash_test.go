@@ -0,0 +1,49 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestTrainingHashSize(t *testing.T) {
+	data, err := os.ReadFile("testdata/en_shakespeare.txt")
+	if err != nil {
+		t.Skip("corpus not available")
+	}
+	data = data[:1 << 18]
+	inputs := [][]byte{data}
+
+	plain := Train(inputs)
+	large := Train(inputs, WithTrainingHashSize(8192))
+
+	// The enlarged hash exists only during training.
+	if large.trainHash != nil {
+		t.Fatalf("trainHash not released after training")
+	}
+
+	// Fewer collision-dropped candidates must not cost compression; allow a
+	// sliver of jitter since a different symbol mix shifts the parse.
+	holdout := makeSample(inputs)
+	p, l := plain.EstimateRatio(holdout), large.EstimateRatio(holdout)
+	if l > p*1.02 {
+		t.Fatalf("larger training hash ratio %f worse than default %f", l, p)
+	}
+
+	// Roundtrip and determinism with the option set.
+	comp := large.Encode(nil, data[:4096])
+	if got := large.Decode(nil, comp); !bytes.Equal(got, data[:4096]) {
+		t.Fatalf("roundtrip failed with WithTrainingHashSize")
+	}
+	var a, b bytes.Buffer
+	if _, err := large.WriteTo(&a); err != nil {
+		t.Fatal(err)
+	}
+	again := Train(inputs, WithTrainingHashSize(8192))
+	if _, err := again.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Fatalf("training with WithTrainingHashSize is not deterministic")
+	}
+}
@@ -0,0 +1,39 @@
+package fsst
+
+// TrainReport describes what happened during training: how much sample data
+// was used, how many rounds ran, and how many ranked candidates never made it
+// into the table. Drops are aggregated over all rounds — early-round drops
+// matter because they skew the counts later rounds are built on. A high
+// collision count for a length class suggests trying WithTrainingHashSize.
+// The report is purely diagnostic; collecting it does not change the table.
+type TrainReport struct {
+	Rounds             int    // training iterations run
+	SampleBytes        int    // total bytes in the training sample
+	CapacityDropped    int    // candidates ranked but left out once the table was full
+	CollisionDropped   int    // 3-8 byte candidates lost to a taken hash slot
+	CollisionsByLength [8]int // collision drops indexed by symbol length minus one
+}
+
+// TrainWithReport is Train plus a TrainReport explaining where candidate
+// symbols were lost, for diagnosing an underperforming table.
+func TrainWithReport(inputs [][]byte, opts ...TrainOption) (*Table, TrainReport) {
+	var cfg trainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var report TrainReport
+	cfg.report = &report
+
+	if cfg.skipThreshold > 0 {
+		inputs = filterIncompressible(inputs, cfg)
+	}
+	sample := makeSampleLined(inputs, fsstSampleTarget, cfg.lineSize())
+	if cfg.fullCoverage {
+		sample = makeSampleFullCoverage(inputs, fsstSampleTarget, cfg.lineSize())
+	}
+	for i := range sample {
+		report.SampleBytes += len(sample[i])
+	}
+	table, _ := trainFromSample(nil, sample, cfg)
+	return table, report
+}
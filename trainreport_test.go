@@ -0,0 +1,49 @@
+package fsst
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestTrainWithReport(t *testing.T) {
+	data, err := os.ReadFile("testdata/en_shakespeare.txt")
+	if err != nil {
+		t.Skip("corpus not available")
+	}
+	data = data[:1<<18]
+	inputs := [][]byte{data}
+
+	tbl, report := TrainWithReport(inputs)
+
+	if report.Rounds != 5 {
+		t.Fatalf("report shows %d rounds, want 5", report.Rounds)
+	}
+	if report.SampleBytes == 0 {
+		t.Fatalf("report shows empty sample")
+	}
+	var byLen int
+	for _, n := range report.CollisionsByLength {
+		byLen += n
+	}
+	if byLen != report.CollisionDropped {
+		t.Fatalf("CollisionsByLength sums to %d, CollisionDropped is %d", byLen, report.CollisionDropped)
+	}
+	// A diverse corpus overflows the candidate ranking every round.
+	if report.CapacityDropped == 0 {
+		t.Fatalf("expected capacity drops on a diverse corpus")
+	}
+
+	// Collecting the report must not change the table.
+	plain := Train(inputs)
+	var a, b bytes.Buffer
+	if _, err := tbl.WriteTo(&a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a.Bytes(), b.Bytes()) {
+		t.Fatalf("TrainWithReport produced a different table than Train")
+	}
+}
@@ -0,0 +1,87 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+// uniformTable builds a finalized table whose symbols are all two bytes, the
+// shape Train produces on fixed-width data like IP address octets.
+func uniformTable() *Table {
+	tbl := newTable()
+	for _, s := range []string{"10", "19", "25", "2.", "0.", "8.", ".1", ".2"} {
+		tbl.addSymbol(newSymbolFromBytes([]byte(s)))
+	}
+	tbl.finalize()
+	return tbl
+}
+
+func TestUniformLenDetection(t *testing.T) {
+	tbl := uniformTable()
+	if tbl.uniformLen != 2 {
+		t.Fatalf("uniformLen = %d, want 2", tbl.uniformLen)
+	}
+
+	mixed := newTable()
+	mixed.addSymbol(newSymbolFromBytes([]byte("ab")))
+	mixed.addSymbol(newSymbolFromBytes([]byte("cde")))
+	mixed.finalize()
+	if mixed.uniformLen != 0 {
+		t.Fatalf("uniformLen = %d on mixed lengths, want 0", mixed.uniformLen)
+	}
+}
+
+func TestUniformDecodeMatchesGeneral(t *testing.T) {
+	tbl := uniformTable()
+	input := []byte(bytes.Repeat([]byte("192.168.10.25"), 50))
+	compressed := tbl.EncodeAll(input)
+
+	got := tbl.Decode(nil, compressed)
+
+	// Force the general path on the same table and stream.
+	tbl.uniformLen = 0
+	want := tbl.Decode(nil, compressed)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("uniform decode diverges from general path")
+	}
+	if !bytes.Equal(got, input) {
+		t.Fatalf("uniform decode roundtrip mismatch")
+	}
+}
+
+func TestUniformLenSurvivesSerialization(t *testing.T) {
+	tbl := uniformTable()
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFrom(&buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if tbl2.uniformLen != 2 {
+		t.Fatalf("uniformLen = %d after roundtrip, want 2", tbl2.uniformLen)
+	}
+}
+
+func BenchmarkDecodeUniform(b *testing.B) {
+	tbl := uniformTable()
+	input := bytes.Repeat([]byte("192.168.10.25"), 1000)
+	compressed := tbl.EncodeAll(input)
+	buf := make([]byte, 0, len(input)+8)
+
+	b.Run("uniform", func(b *testing.B) {
+		b.SetBytes(int64(len(input)))
+		for i := 0; i < b.N; i++ {
+			buf = tbl.Decode(buf, compressed)
+		}
+	})
+	b.Run("general", func(b *testing.B) {
+		tbl.uniformLen = 0
+		defer func() { tbl.uniformLen = 2 }()
+		b.SetBytes(int64(len(input)))
+		for i := 0; i < b.N; i++ {
+			buf = tbl.Decode(buf, compressed)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package fsst
+
+import "encoding/binary"
+
+// SymbolUsage returns, per assigned code, how many times each learned symbol
+// fires when encoding input. Escaped literal bytes are counted under key -1.
+// It is a debugging aid for diagnosing poor ratios (e.g. long symbols never
+// matching), so it favors clarity over speed: it encodes input and tallies
+// the code stream.
+func (t *Table) SymbolUsage(input []byte) map[int]int {
+	comp := t.Encode(nil, input)
+	usage := make(map[int]int)
+	for srcPos := 0; srcPos < len(comp); {
+		b := comp[srcPos]
+		srcPos++
+		switch {
+		case b == fsstEscapeCode:
+			usage[-1]++
+			srcPos++ // skip literal
+		case t.extended && b == fsstExtendedPrefix:
+			if srcPos < len(comp) {
+				usage[fsstExtendedPrefix+int(comp[srcPos])]++
+				srcPos++
+			}
+		default:
+			usage[int(b)]++
+		}
+	}
+	return usage
+}
+
+// NumSymbols returns the number of learned symbols in the table.
+func (t *Table) NumSymbols() int {
+	return int(t.nSymbols)
+}
+
+// Symbols returns the bytes of every learned symbol in code order, codes
+// 0..NumSymbols()-1. It is the public, stable view of the learned vocabulary,
+// independent of the on-disk format; each entry is freshly allocated, so
+// callers may keep or modify them.
+func (t *Table) Symbols() [][]byte {
+	out := make([][]byte, t.nSymbols)
+	for code := range int(t.nSymbols) {
+		out[code] = t.SymbolOf(code)
+	}
+	return out
+}
+
+// SymbolsWithPrefix returns, in code order, the bytes of every learned
+// symbol whose first byte equals b. It is a reverse-index view of the learned
+// vocabulary, handy for understanding why certain substrings don't compress.
+func (t *Table) SymbolsWithPrefix(b byte) [][]byte {
+	var out [][]byte
+	for code := range int(t.nSymbols) {
+		if sym := t.SymbolOf(code); len(sym) > 0 && sym[0] == b {
+			out = append(out, sym)
+		}
+	}
+	return out
+}
+
+// SymbolOf returns the bytes of the learned symbol with the given code, or
+// nil if the code is not assigned. Useful together with SymbolUsage. The
+// code is the wire code Encode emits, so frequency-reassigned tables
+// translate through their remap.
+func (t *Table) SymbolOf(code int) []byte {
+	if code < 0 || code >= int(t.nSymbols) {
+		return nil
+	}
+	if t.freqInv != nil {
+		code = int(t.freqInv[code])
+	}
+	sym := t.symbols[code]
+	symbolLength := int(sym.length())
+	raw := make([]byte, symbolLength)
+	for byteIdx := range symbolLength {
+		raw[byteIdx] = byte(sym.val >> (8 * byteIdx))
+	}
+	return raw
+}
+
+// SymbolBytes returns the decoded bytes for one learned code and whether the
+// code is assigned; escapes, macro codes, and out-of-range values report
+// false. It reads the decoder arrays (building them lazily on first use), so
+// it is the single-code building block for custom decode loops — columnar
+// engines that store raw codes can materialize one value without walking a
+// stream. The returned slice is freshly allocated.
+func (t *Table) SymbolBytes(code int) ([]byte, bool) {
+	if code < 0 || code >= int(t.nSymbols) {
+		return nil, false
+	}
+	var tmp [8]byte
+	if t.extended {
+		t.ensureDecoderExtended()
+		binary.LittleEndian.PutUint64(tmp[:], t.decSymbolExt[code])
+		return append([]byte(nil), tmp[:t.decLenExt[code]]...), true
+	}
+	t.ensureDecoder()
+	binary.LittleEndian.PutUint64(tmp[:], t.decSymbol[code])
+	return append([]byte(nil), tmp[:t.decLen[code]]...), true
+}
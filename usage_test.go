@@ -0,0 +1,132 @@
+package fsst
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSymbolUsage(t *testing.T) {
+	input := []byte(strings.Repeat("hello ", 100))
+	tbl := Train([][]byte{input})
+
+	usage := tbl.SymbolUsage(input)
+	if len(usage) == 0 {
+		t.Fatalf("no symbol usage reported")
+	}
+
+	// The dominant code should decode to a chunk of the repeated pattern.
+	bestCode, bestCount := -1, 0
+	total := 0
+	for code, count := range usage {
+		total += count
+		if code >= 0 && count > bestCount {
+			bestCode, bestCount = code, count
+		}
+	}
+	if bestCode < 0 {
+		t.Fatalf("everything escaped: %v", usage)
+	}
+	sym := tbl.SymbolOf(bestCode)
+	if !bytes.Contains([]byte(strings.Repeat("hello ", 3)), sym) {
+		t.Fatalf("dominant symbol %q not part of the repeated pattern", sym)
+	}
+	if escapes := usage[-1]; escapes*2 > total {
+		t.Fatalf("escapes dominate (%d of %d) on repetitive input", escapes, total)
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	input := []byte(strings.Repeat("hello world ", 100))
+	tbl := Train([][]byte{input})
+
+	symbols := tbl.Symbols()
+	if len(symbols) != tbl.NumSymbols() {
+		t.Fatalf("len(Symbols()) = %d, want NumSymbols() = %d", len(symbols), tbl.NumSymbols())
+	}
+	if len(symbols) == 0 {
+		t.Fatalf("no symbols learned on repetitive input")
+	}
+	for code, sym := range symbols {
+		if len(sym) < 1 || len(sym) > 8 {
+			t.Fatalf("symbol %d has length %d, want 1..8", code, len(sym))
+		}
+		if !bytes.Equal(sym, tbl.SymbolOf(code)) {
+			t.Fatalf("Symbols()[%d] = %q, SymbolOf = %q", code, sym, tbl.SymbolOf(code))
+		}
+	}
+
+	// Each symbol must decode from its own code.
+	for code, sym := range symbols {
+		if code >= 255 {
+			break // extended codes need a prefix byte, skip here
+		}
+		got := tbl.Decode(nil, []byte{byte(code)})
+		if !bytes.Equal(got, sym) {
+			t.Fatalf("code %d decodes to %q, Symbols says %q", code, got, sym)
+		}
+	}
+}
+
+func TestSymbolsWithPrefix(t *testing.T) {
+	input := []byte(strings.Repeat("hello world ", 100))
+	tbl := Train([][]byte{input})
+
+	withH := tbl.SymbolsWithPrefix('h')
+	if len(withH) == 0 {
+		t.Fatalf("no symbols start with 'h' on hello-heavy input")
+	}
+	for _, sym := range withH {
+		if sym[0] != 'h' {
+			t.Fatalf("symbol %q does not start with 'h'", sym)
+		}
+	}
+	// A byte absent from the corpus has no symbols.
+	if got := tbl.SymbolsWithPrefix(0xFE); got != nil {
+		t.Fatalf("unexpected symbols for absent byte: %q", got)
+	}
+}
+
+func TestSymbolOfOutOfRange(t *testing.T) {
+	tbl := Train([][]byte{[]byte("abc")})
+	if got := tbl.SymbolOf(-1); got != nil {
+		t.Fatalf("expected nil for negative code")
+	}
+	if got := tbl.SymbolOf(int(tbl.nSymbols)); got != nil {
+		t.Fatalf("expected nil for out-of-range code")
+	}
+}
+
+func TestSymbolBytes(t *testing.T) {
+	corpus := []byte(strings.Repeat("materialize one value by code ", 100))
+	tbl := Train([][]byte{corpus})
+
+	// Every assigned code decodes to the same bytes SymbolOf reports.
+	for code := 0; code < int(tbl.nSymbols); code++ {
+		sym, ok := tbl.SymbolBytes(code)
+		if !ok {
+			t.Fatalf("code %d: SymbolBytes reported unassigned", code)
+		}
+		if !bytes.Equal(sym, tbl.SymbolOf(code)) {
+			t.Fatalf("code %d: SymbolBytes = %q, SymbolOf = %q", code, sym, tbl.SymbolOf(code))
+		}
+	}
+	if _, ok := tbl.SymbolBytes(-1); ok {
+		t.Fatal("expected false for negative code")
+	}
+	if _, ok := tbl.SymbolBytes(int(tbl.nSymbols)); ok {
+		t.Fatal("expected false for out-of-range code")
+	}
+	if _, ok := tbl.SymbolBytes(int(fsstEscapeCode)); ok {
+		t.Fatal("expected false for the escape code")
+	}
+
+	// Extended tables read the extended decoder arrays.
+	ext := Train([][]byte{corpus}, WithExtendedCodes(true))
+	for code := 0; code < int(ext.nSymbols); code++ {
+		sym, ok := ext.SymbolBytes(code)
+		if !ok || !bytes.Equal(sym, ext.SymbolOf(code)) {
+			t.Fatalf("extended code %d: SymbolBytes = %q (%v), SymbolOf = %q", code, sym, ok, ext.SymbolOf(code))
+		}
+	}
+}
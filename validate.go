@@ -0,0 +1,33 @@
+package fsst
+
+import "fmt"
+
+// Validate checks the Table's internal consistency. It is intended for
+// tables deserialized from untrusted sources (shared caches, network) so
+// garbage can be rejected before it silently decodes into nonsense.
+func (t *Table) Validate() error {
+	maxSymbols := fsstMaxSymbols
+	if t.extended {
+		maxSymbols = fsstMaxSymbolsExtended
+	}
+	if int(t.nSymbols) > maxSymbols {
+		return fmt.Errorf("fsst: nSymbols %d exceeds maximum %d", t.nSymbols, maxSymbols)
+	}
+	if t.suffixLim > t.nSymbols {
+		return fmt.Errorf("fsst: suffixLim %d exceeds nSymbols %d", t.suffixLim, t.nSymbols)
+	}
+	var histoSum uint16
+	for i := range t.lenHisto {
+		histoSum += t.lenHisto[i]
+	}
+	if histoSum != t.nSymbols {
+		return fmt.Errorf("fsst: lenHisto sums to %d, want nSymbols %d", histoSum, t.nSymbols)
+	}
+	for i := range int(t.nSymbols) {
+		length := t.symbols[i].length()
+		if length < 1 || length > 8 {
+			return fmt.Errorf("fsst: symbol %d has invalid length %d", i, length)
+		}
+	}
+	return nil
+}
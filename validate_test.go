@@ -0,0 +1,47 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tbl := Train([][]byte{[]byte("valid training data, repeated words words words")})
+	if err := tbl.Validate(); err != nil {
+		t.Fatalf("trained table failed validation: %v", err)
+	}
+
+	// Serialization roundtrip stays valid.
+	var buf bytes.Buffer
+	if _, err := tbl.WriteTo(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var tbl2 Table
+	if _, err := tbl2.ReadFrom(&buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if err := tbl2.Validate(); err != nil {
+		t.Fatalf("deserialized table failed validation: %v", err)
+	}
+
+	// Corruptions are rejected.
+	bad := *tbl
+	bad.suffixLim = bad.nSymbols + 1
+	if err := bad.Validate(); err == nil {
+		t.Fatalf("expected error for suffixLim > nSymbols")
+	}
+
+	bad = *tbl
+	bad.lenHisto[0]++
+	if err := bad.Validate(); err == nil {
+		t.Fatalf("expected error for inconsistent lenHisto")
+	}
+
+	bad = *tbl
+	if bad.nSymbols > 0 {
+		bad.symbols[0].setCodeLen(0, 12)
+		if err := bad.Validate(); err == nil {
+			t.Fatalf("expected error for invalid symbol length")
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package fsst
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetVariantSticks(t *testing.T) {
+	input := bytes.Repeat([]byte("ababababcdcdcdcd"), 64)
+	for _, noSuffix := range []bool{false, true} {
+		for _, avoidBranch := range []bool{false, true} {
+			tbl := Train([][]byte{input})
+			tbl.SetVariant(noSuffix, avoidBranch)
+			comp := tbl.EncodeAll(input) // triggers lazy init
+			if tbl.noSuffixOpt != noSuffix || tbl.avoidBranch != avoidBranch {
+				t.Fatalf("variant (%v,%v) overwritten by lazy init: got (%v,%v)",
+					noSuffix, avoidBranch, tbl.noSuffixOpt, tbl.avoidBranch)
+			}
+			got := tbl.DecodeAll(comp)
+			if !bytes.Equal(got, input) {
+				t.Fatalf("roundtrip mismatch with variant (%v,%v)", noSuffix, avoidBranch)
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package fsst
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTrainVerified(t *testing.T) {
+	inputs := [][]byte{
+		[]byte(strings.Repeat(`{"level":"info","msg":"request served"}`, 50)),
+		[]byte("short"),
+		{},
+		noiseBlob(1000),
+		[]byte("héllo wörld with high-bit bytes \xff\xfe"),
+	}
+
+	tbl, err := TrainVerified(inputs)
+	if err != nil {
+		t.Fatalf("TrainVerified: %v", err)
+	}
+	if tbl == nil || tbl.nSymbols == 0 {
+		t.Fatalf("TrainVerified returned an untrained table")
+	}
+}